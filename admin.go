@@ -0,0 +1,216 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+)
+
+// ErrWriteNotSupported is returned by administrative methods when the
+// underlying client does not support write operations.
+var ErrWriteNotSupported = errors.New("secret manager client does not support write operations")
+
+// secretWriterClient is implemented by clients that can modify secrets. It is
+// kept apart from SecretManagerClient so read-only clients are not affected.
+type secretWriterClient interface {
+	AddSecretVersion(ctx context.Context, req *secretmanagerpb.AddSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error)
+	DestroySecretVersion(ctx context.Context, req *secretmanagerpb.DestroySecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error)
+	DisableSecretVersion(ctx context.Context, req *secretmanagerpb.DisableSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error)
+	EnableSecretVersion(ctx context.Context, req *secretmanagerpb.EnableSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error)
+}
+
+var _ secretWriterClient = (*secretmanager.Client)(nil)
+
+// AddSecretVersion adds a new version containing payload to the configured
+// secret. Unless checksum verification is disabled, the CRC32C checksum of
+// the payload is sent along so Secret Manager can reject corrupted writes.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - payload: The secret data to store in the new version.
+//
+// Returns:
+// - The resource name of the new version, for example
+// "projects/PROJECT_ID/secrets/SECRET_NAME/versions/8".
+// - An error if the client does not support writes or the API call fails.
+func (c *Client) AddSecretVersion(ctx context.Context, payload []byte) (string, error) {
+	writer, ok := c.client.(secretWriterClient)
+	if !ok {
+		return "", ErrWriteNotSupported
+	}
+
+	// Create the request to add a version to the configured secret
+	req := &secretmanagerpb.AddSecretVersionRequest{
+		Parent: c.config.secretPath(c.config.SecretName),
+		Payload: &secretmanagerpb.SecretPayload{
+			Data: payload,
+		},
+	}
+	if !c.config.DisableChecksum {
+		sum := int64(crc32.Checksum(payload, crc32cTable))
+		req.Payload.DataCrc32C = &sum
+	}
+
+	// Add a timeout to the context to limit the duration of the API call.
+	// Writes are not retried since they are not idempotent.
+	ctx, cancel := c.config.callContext(ctx)
+	defer cancel()
+
+	version, err := writer.AddSecretVersion(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to add secret version: %w", classifyError(err))
+	}
+
+	return version.GetName(), nil
+}
+
+// DestroySecretVersion irreversibly destroys the given version of the
+// configured secret. The version must be an explicit version number or
+// alias; "latest" is rejected since the version it points to may change.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - version: The version of the secret to destroy.
+//
+// Returns:
+// - An error wrapping ErrInvalidVersion if version is empty or "latest".
+// - An error if the client does not support writes or the API call fails.
+func (c *Client) DestroySecretVersion(ctx context.Context, version string) error {
+	return c.versionAction(ctx, version, "destroy", func(ctx context.Context, writer secretWriterClient, name string) error {
+		_, err := writer.DestroySecretVersion(ctx, &secretmanagerpb.DestroySecretVersionRequest{Name: name})
+		return err
+	})
+}
+
+// DisableSecretVersion disables the given version of the configured secret.
+// A disabled version can no longer be accessed, but unlike a destroyed one it
+// can be enabled again. The version must be an explicit version number or
+// alias; "latest" is rejected since the version it points to may change.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - version: The version of the secret to disable.
+//
+// Returns:
+// - An error wrapping ErrInvalidVersion if version is empty or "latest".
+// - An error if the client does not support writes or the API call fails.
+func (c *Client) DisableSecretVersion(ctx context.Context, version string) error {
+	return c.versionAction(ctx, version, "disable", func(ctx context.Context, writer secretWriterClient, name string) error {
+		_, err := writer.DisableSecretVersion(ctx, &secretmanagerpb.DisableSecretVersionRequest{Name: name})
+		return err
+	})
+}
+
+// EnableSecretVersion enables the given disabled version of the configured
+// secret so it can be accessed again, for example to roll back a rotation.
+// Destroyed versions cannot be enabled. The version must be an explicit
+// version number or alias; "latest" is rejected since it only ever points to
+// an enabled version.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - version: The version of the secret to enable.
+//
+// Returns:
+// - An error wrapping ErrInvalidVersion if version is empty or "latest".
+// - An error if the client does not support writes or the API call fails.
+func (c *Client) EnableSecretVersion(ctx context.Context, version string) error {
+	return c.versionAction(ctx, version, "enable", func(ctx context.Context, writer secretWriterClient, name string) error {
+		_, err := writer.EnableSecretVersion(ctx, &secretmanagerpb.EnableSecretVersionRequest{Name: name})
+		return err
+	})
+}
+
+// versionAction validates version and calls fn with the writer client and
+// the resource name of that version of the configured secret, under the
+// per-call timeout. Writes are not retried since they are not idempotent.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - version: The explicit version the action applies to.
+// - verb: The action, such as "disable", used in error messages.
+// - fn: The function calling the API.
+//
+// Returns:
+// - An error wrapping ErrInvalidVersion if version is empty or "latest".
+// - ErrWriteNotSupported if the client does not support writes.
+// - The error returned by fn, classified like read errors.
+func (c *Client) versionAction(ctx context.Context, version, verb string, fn func(ctx context.Context, writer secretWriterClient, name string) error) error {
+	if version == LatestVersion {
+		return fmt.Errorf("%w: refusing to %s %q, use an explicit version", ErrInvalidVersion, verb, version)
+	}
+	if err := validateVersion(version); err != nil {
+		return err
+	}
+
+	writer, ok := c.client.(secretWriterClient)
+	if !ok {
+		return ErrWriteNotSupported
+	}
+
+	// Add a timeout to the context to limit the duration of the API call
+	ctx, cancel := c.config.callContext(ctx)
+	defer cancel()
+
+	if err := fn(ctx, writer, c.config.versionPath(c.config.SecretName, version)); err != nil {
+		return fmt.Errorf("failed to %s secret version %s: %w", verb, version, classifyError(err))
+	}
+	return nil
+}
+
+// RotateSecret adds a new version containing newPayload to the configured
+// secret, then disables the version that was the most recent enabled one
+// before the call. When the secret has no enabled version, only the new
+// version is added.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - newPayload: The secret data to store in the new version.
+//
+// Returns:
+// - The resource name of the new version, also returned when disabling the
+// previous version fails, since the new version was added.
+// - An error if listing the versions or adding the new one fails, in which
+// case nothing was changed, or an error stating that the new version was
+// added if disabling the previous version fails.
+func (c *Client) RotateSecret(ctx context.Context, newPayload []byte) (string, error) {
+	// Find the previous version before adding the new one
+	versions, err := c.listEnabledVersions(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to rotate secret: %w", err)
+	}
+	previous := latestVersionNumber(versions)
+
+	newVersion, err := c.AddSecretVersion(ctx, newPayload)
+	if err != nil {
+		return "", fmt.Errorf("failed to rotate secret: %w", err)
+	}
+
+	if previous == "" {
+		return newVersion, nil
+	}
+	if err := c.DisableSecretVersion(ctx, previous); err != nil {
+		return newVersion, fmt.Errorf("added secret version %s but failed to disable previous version: %w", newVersion, err)
+	}
+
+	return newVersion, nil
+}
+
+// latestVersionNumber returns the highest numeric version among versions, or
+// an empty string if there is none.
+func latestVersionNumber(versions []string) string {
+	latest, latestNum := "", -1
+	for _, version := range versions {
+		num, err := strconv.Atoi(version)
+		if err == nil && num > latestNum {
+			latest, latestNum = version, num
+		}
+	}
+	return latest
+}
@@ -0,0 +1,434 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"testing"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockWriterClient extends the read mock with write operations.
+type mockWriterClient struct {
+	mockSecretManagerClient
+	isWriteSuccess bool
+	addRequests    []*secretmanagerpb.AddSecretVersionRequest
+	destroyed      []string
+	// disableErr is returned by DisableSecretVersion when set
+	disableErr error
+	// writeErr is returned by every write operation when set
+	writeErr error
+	// sequence records the write operations in call order
+	sequence []string
+}
+
+func (m *mockWriterClient) AddSecretVersion(ctx context.Context, req *secretmanagerpb.AddSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	m.addRequests = append(m.addRequests, req)
+	m.sequence = append(m.sequence, "add "+req.Parent)
+	if m.writeErr != nil {
+		return nil, m.writeErr
+	}
+	if !m.isWriteSuccess {
+		return nil, fmt.Errorf("write error")
+	}
+	return &secretmanagerpb.SecretVersion{Name: req.Parent + "/versions/2"}, nil
+}
+
+func (m *mockWriterClient) DestroySecretVersion(ctx context.Context, req *secretmanagerpb.DestroySecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	m.destroyed = append(m.destroyed, req.Name)
+	m.sequence = append(m.sequence, "destroy "+req.Name)
+	if m.writeErr != nil {
+		return nil, m.writeErr
+	}
+	if !m.isWriteSuccess {
+		return nil, fmt.Errorf("write error")
+	}
+	return &secretmanagerpb.SecretVersion{Name: req.Name, State: secretmanagerpb.SecretVersion_DESTROYED}, nil
+}
+
+func (m *mockWriterClient) DisableSecretVersion(ctx context.Context, req *secretmanagerpb.DisableSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	m.sequence = append(m.sequence, "disable "+req.Name)
+	if m.disableErr != nil {
+		return nil, m.disableErr
+	}
+	if m.writeErr != nil {
+		return nil, m.writeErr
+	}
+	if !m.isWriteSuccess {
+		return nil, fmt.Errorf("write error")
+	}
+	return &secretmanagerpb.SecretVersion{Name: req.Name, State: secretmanagerpb.SecretVersion_DISABLED}, nil
+}
+
+func (m *mockWriterClient) EnableSecretVersion(ctx context.Context, req *secretmanagerpb.EnableSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	m.sequence = append(m.sequence, "enable "+req.Name)
+	if m.writeErr != nil {
+		return nil, m.writeErr
+	}
+	if !m.isWriteSuccess {
+		return nil, fmt.Errorf("write error")
+	}
+	return &secretmanagerpb.SecretVersion{Name: req.Name, State: secretmanagerpb.SecretVersion_ENABLED}, nil
+}
+
+func TestAddSecretVersion(t *testing.T) {
+	ctx := context.Background()
+	payload := []byte("FOO=bar")
+	sum := int64(crc32.Checksum(payload, crc32.MakeTable(crc32.Castagnoli)))
+
+	testCases := []struct {
+		name            string
+		client          SecretManagerClient
+		disableChecksum bool
+		expectedName    string
+		expectedCRC     *int64
+		expectedErr     error
+	}{
+		{
+			name:         "success with checksum",
+			client:       &mockWriterClient{isWriteSuccess: true},
+			expectedName: "projects/test-id/secrets/test-name/versions/2",
+			expectedCRC:  &sum,
+		},
+		{
+			name:            "success without checksum",
+			client:          &mockWriterClient{isWriteSuccess: true},
+			disableChecksum: true,
+			expectedName:    "projects/test-id/secrets/test-name/versions/2",
+		},
+		{
+			name:        "fail to add secret version",
+			client:      &mockWriterClient{},
+			expectedErr: fmt.Errorf("failed to add secret version"),
+		},
+		{
+			name:        "fail with read-only client",
+			client:      &mockSecretManagerClient{},
+			expectedErr: ErrWriteNotSupported,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: tc.client,
+				config: &Config{
+					ProjectID:       "test-id",
+					SecretName:      "test-name",
+					DisableChecksum: tc.disableChecksum,
+				},
+			}
+
+			name, err := c.AddSecretVersion(ctx, payload)
+			if tc.expectedErr != nil {
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedName, name)
+
+			req := tc.client.(*mockWriterClient).addRequests[0]
+			assert.Equal(t, payload, req.Payload.Data)
+			assert.Equal(t, tc.expectedCRC, req.Payload.DataCrc32C)
+		})
+	}
+}
+
+func TestDestroySecretVersion(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name          string
+		client        SecretManagerClient
+		version       string
+		expectedNames []string
+		expectedErr   error
+	}{
+		{
+			name:          "success destroy version",
+			client:        &mockWriterClient{isWriteSuccess: true},
+			version:       "3",
+			expectedNames: []string{"projects/test-id/secrets/test-name/versions/3"},
+		},
+		{
+			name:        "fail with empty version",
+			client:      &mockWriterClient{isWriteSuccess: true},
+			version:     "",
+			expectedErr: ErrInvalidVersion,
+		},
+		{
+			name:        "fail with latest version",
+			client:      &mockWriterClient{isWriteSuccess: true},
+			version:     "latest",
+			expectedErr: ErrInvalidVersion,
+		},
+		{
+			name:          "fail to destroy secret version",
+			client:        &mockWriterClient{},
+			version:       "3",
+			expectedNames: []string{"projects/test-id/secrets/test-name/versions/3"},
+			expectedErr:   fmt.Errorf("failed to destroy secret version 3"),
+		},
+		{
+			name:        "fail with read-only client",
+			client:      &mockSecretManagerClient{},
+			version:     "3",
+			expectedErr: ErrWriteNotSupported,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: tc.client,
+				config: &Config{ProjectID: "test-id", SecretName: "test-name"},
+			}
+
+			err := c.DestroySecretVersion(ctx, tc.version)
+			if tc.expectedErr != nil {
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			if writer, ok := tc.client.(*mockWriterClient); ok {
+				assert.Equal(t, tc.expectedNames, writer.destroyed)
+			}
+		})
+	}
+}
+
+func TestDisableSecretVersion(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name             string
+		client           SecretManagerClient
+		version          string
+		expectedSequence []string
+		expectedErr      error
+	}{
+		{
+			name:             "success disable version",
+			client:           &mockWriterClient{isWriteSuccess: true},
+			version:          "3",
+			expectedSequence: []string{"disable projects/test-id/secrets/test-name/versions/3"},
+		},
+		{
+			name:        "fail with latest version",
+			client:      &mockWriterClient{isWriteSuccess: true},
+			version:     "latest",
+			expectedErr: ErrInvalidVersion,
+		},
+		{
+			name:             "fail to disable secret version",
+			client:           &mockWriterClient{},
+			version:          "3",
+			expectedSequence: []string{"disable projects/test-id/secrets/test-name/versions/3"},
+			expectedErr:      fmt.Errorf("failed to disable secret version 3"),
+		},
+		{
+			name:        "fail with read-only client",
+			client:      &mockSecretManagerClient{},
+			version:     "3",
+			expectedErr: ErrWriteNotSupported,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: tc.client,
+				config: &Config{ProjectID: "test-id", SecretName: "test-name"},
+			}
+
+			err := c.DisableSecretVersion(ctx, tc.version)
+			if tc.expectedErr != nil {
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			if writer, ok := tc.client.(*mockWriterClient); ok {
+				assert.Equal(t, tc.expectedSequence, writer.sequence)
+			}
+		})
+	}
+}
+
+func TestEnableSecretVersion(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name             string
+		client           SecretManagerClient
+		version          string
+		expectedSequence []string
+		expectedErr      error
+	}{
+		{
+			name:             "success enable version",
+			client:           &mockWriterClient{isWriteSuccess: true},
+			version:          "3",
+			expectedSequence: []string{"enable projects/test-id/secrets/test-name/versions/3"},
+		},
+		{
+			name:        "fail with empty version",
+			client:      &mockWriterClient{isWriteSuccess: true},
+			version:     "",
+			expectedErr: ErrInvalidVersion,
+		},
+		{
+			name:        "fail with latest version",
+			client:      &mockWriterClient{isWriteSuccess: true},
+			version:     "latest",
+			expectedErr: ErrInvalidVersion,
+		},
+		{
+			name:             "fail to enable secret version",
+			client:           &mockWriterClient{},
+			version:          "3",
+			expectedSequence: []string{"enable projects/test-id/secrets/test-name/versions/3"},
+			expectedErr:      fmt.Errorf("failed to enable secret version 3"),
+		},
+		{
+			name:        "fail with read-only client",
+			client:      &mockSecretManagerClient{},
+			version:     "3",
+			expectedErr: ErrWriteNotSupported,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: tc.client,
+				config: &Config{ProjectID: "test-id", SecretName: "test-name"},
+			}
+
+			err := c.EnableSecretVersion(ctx, tc.version)
+			if tc.expectedErr != nil {
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			if writer, ok := tc.client.(*mockWriterClient); ok {
+				assert.Equal(t, tc.expectedSequence, writer.sequence)
+			}
+		})
+	}
+}
+
+func TestRotateSecret(t *testing.T) {
+	originalSecretVersionIterator := newSecretVersionIterator
+	defer func() { newSecretVersionIterator = originalSecretVersionIterator }()
+
+	const prefix = "projects/test-id/secrets/test-name"
+
+	testCases := []struct {
+		name             string
+		client           *mockWriterClient
+		versions         []*secretmanagerpb.SecretVersion
+		expectedName     string
+		expectedSequence []string
+		expectedErr      string
+	}{
+		{
+			name:   "add then disable the latest enabled version",
+			client: &mockWriterClient{isWriteSuccess: true},
+			versions: []*secretmanagerpb.SecretVersion{
+				{Name: prefix + "/versions/10", State: secretmanagerpb.SecretVersion_DISABLED},
+				{Name: prefix + "/versions/9", State: secretmanagerpb.SecretVersion_ENABLED},
+				{Name: prefix + "/versions/1", State: secretmanagerpb.SecretVersion_ENABLED},
+			},
+			expectedName:     prefix + "/versions/2",
+			expectedSequence: []string{"add " + prefix, "disable " + prefix + "/versions/9"},
+		},
+		{
+			name:             "add only without enabled version",
+			client:           &mockWriterClient{isWriteSuccess: true},
+			expectedName:     prefix + "/versions/2",
+			expectedSequence: []string{"add " + prefix},
+		},
+		{
+			name:   "fail to add the new version",
+			client: &mockWriterClient{},
+			versions: []*secretmanagerpb.SecretVersion{
+				{Name: prefix + "/versions/1", State: secretmanagerpb.SecretVersion_ENABLED},
+			},
+			expectedSequence: []string{"add " + prefix},
+			expectedErr:      "failed to rotate secret: failed to add secret version",
+		},
+		{
+			name:   "fail to disable the previous version",
+			client: &mockWriterClient{isWriteSuccess: true, disableErr: fmt.Errorf("disable error")},
+			versions: []*secretmanagerpb.SecretVersion{
+				{Name: prefix + "/versions/1", State: secretmanagerpb.SecretVersion_ENABLED},
+			},
+			expectedName:     prefix + "/versions/2",
+			expectedSequence: []string{"add " + prefix, "disable " + prefix + "/versions/1"},
+			expectedErr:      "added secret version " + prefix + "/versions/2 but failed to disable previous version",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			newSecretVersionIterator = func(ctx context.Context, client SecretManagerClient, req *secretmanagerpb.ListSecretVersionsRequest, opts ...gax.CallOption) (secretVersionIterator, error) {
+				return &mockSecretVersionIterator{pages: [][]*secretmanagerpb.SecretVersion{tc.versions}}, nil
+			}
+
+			c := &Client{
+				client: tc.client,
+				config: &Config{ProjectID: "test-id", SecretName: "test-name"},
+			}
+
+			name, err := c.RotateSecret(context.Background(), []byte("KEY=new"))
+			assert.Equal(t, tc.expectedName, name)
+			assert.Equal(t, tc.expectedSequence, tc.client.sequence)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAdminErrorsClassified(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name        string
+		writeErr    error
+		expectedErr error
+	}{
+		{
+			name:        "not found",
+			writeErr:    status.Error(codes.NotFound, "secret not found"),
+			expectedErr: ErrSecretNotFound,
+		},
+		{
+			name:        "permission denied",
+			writeErr:    status.Error(codes.PermissionDenied, "denied"),
+			expectedErr: ErrPermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: &mockWriterClient{writeErr: tc.writeErr},
+				config: &Config{ProjectID: "test-id", SecretName: "test-name"},
+			}
+
+			_, err := c.AddSecretVersion(ctx, []byte("A=1"))
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.ErrorIs(t, c.DestroySecretVersion(ctx, "3"), tc.expectedErr)
+			assert.ErrorIs(t, c.DisableSecretVersion(ctx, "3"), tc.expectedErr)
+			assert.ErrorIs(t, c.EnableSecretVersion(ctx, "3"), tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package GCPSecretManager
+
+import "fmt"
+
+// BareKeyBehavior controls how lines without a '=' character, such as a
+// bare "DEBUG" flag, are parsed.
+type BareKeyBehavior int
+
+const (
+	// BareKeyError rejects bare keys with a ParseError. It is the default.
+	BareKeyError BareKeyBehavior = iota
+	// BareKeyEmpty sets bare keys to an empty value, as if written "DEBUG=".
+	BareKeyEmpty
+	// BareKeyDefault sets bare keys to Config.BareKeyValue, for example
+	// "true" to read "DEBUG" as "DEBUG=true".
+	BareKeyDefault
+)
+
+// String returns a readable name for the behavior.
+func (b BareKeyBehavior) String() string {
+	switch b {
+	case BareKeyError:
+		return "error"
+	case BareKeyEmpty:
+		return "empty"
+	case BareKeyDefault:
+		return "default"
+	default:
+		return fmt.Sprintf("BareKeyBehavior(%d)", int(b))
+	}
+}
+
+// bareKeyValue returns the value given to a bare key, and false when bare
+// keys are rejected.
+func (c *Config) bareKeyValue() (string, bool) {
+	switch c.BareKeyBehavior {
+	case BareKeyEmpty:
+		return "", true
+	case BareKeyDefault:
+		return c.BareKeyValue, true
+	default:
+		return "", false
+	}
+}
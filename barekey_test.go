@@ -0,0 +1,68 @@
+package GCPSecretManager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSecretBareKeys(t *testing.T) {
+	content := "DEBUG\nHOST=localhost\n"
+
+	testCases := []struct {
+		name        string
+		config      Config
+		content     string
+		expected    map[string]string
+		errContains string
+	}{
+		{
+			name:        "error by default",
+			content:     content,
+			errContains: "invalid format at line 1 (DEBUG): line must contain a '=' character",
+		},
+		{
+			name:     "empty value",
+			config:   Config{BareKeyBehavior: BareKeyEmpty},
+			content:  content,
+			expected: map[string]string{"DEBUG": "", "HOST": "localhost"},
+		},
+		{
+			name:     "default value",
+			config:   Config{BareKeyBehavior: BareKeyDefault, BareKeyValue: "true"},
+			content:  content,
+			expected: map[string]string{"DEBUG": "true", "HOST": "localhost"},
+		},
+		{
+			name:     "surrounding whitespace trimmed",
+			config:   Config{BareKeyBehavior: BareKeyDefault, BareKeyValue: "true"},
+			content:  "  DEBUG  \n",
+			expected: map[string]string{"DEBUG": "true"},
+		},
+		{
+			name:        "bare keys validated",
+			config:      Config{BareKeyBehavior: BareKeyEmpty},
+			content:     "not a key\n",
+			errContains: `invalid key "not a key"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := tc.config.parseSecret(tc.content, nil)
+			if tc.errContains != "" {
+				assert.ErrorContains(t, err, tc.errContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, values)
+		})
+	}
+}
+
+func TestBareKeyBehaviorString(t *testing.T) {
+	assert.Equal(t, "error", BareKeyError.String())
+	assert.Equal(t, "empty", BareKeyEmpty.String())
+	assert.Equal(t, "default", BareKeyDefault.String())
+	assert.Equal(t, "BareKeyBehavior(7)", BareKeyBehavior(7).String())
+}
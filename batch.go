@@ -0,0 +1,106 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+)
+
+// defaultMaxConcurrency is the number of concurrent API calls made by batch
+// operations when Config.MaxConcurrency is zero. Accessing one secret at a
+// time keeps batch loads well within the Secret Manager access quota.
+const defaultMaxConcurrency = 1
+
+// maxConcurrency returns the configured concurrency limit of batch
+// operations or the default one.
+func (c *Config) maxConcurrency() int {
+	if c.MaxConcurrency <= 0 {
+		return defaultMaxConcurrency
+	}
+	return c.MaxConcurrency
+}
+
+// accessResult is the outcome of accessing one secret version of a batch.
+type accessResult struct {
+	response *secretmanagerpb.AccessSecretVersionResponse
+	err      error
+}
+
+// accessAll accesses every referenced secret version, with at most
+// MaxConcurrency calls in flight and each call delayed by a random duration
+// up to BatchJitter. Calls are started in the order of refs.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - refs: The resolved secret versions to access.
+// - stopOnError: Whether to stop starting new calls after a failure.
+//
+// Returns:
+// - The results in the order of refs. When stopOnError is set, the results of
+// the calls that were not started are nil; they always come after a failed
+// result.
+func (c *Client) accessAll(ctx context.Context, refs []SecretRef, stopOnError bool) []*accessResult {
+	results := make([]*accessResult, len(refs))
+	slots := make(chan struct{}, c.config.maxConcurrency())
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed bool
+	)
+
+	for i, ref := range refs {
+		slots <- struct{}{}
+
+		mu.Lock()
+		stop := failed && stopOnError
+		mu.Unlock()
+		if stop {
+			<-slots
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, ref SecretRef) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			result := &accessResult{err: c.config.jitter(ctx)}
+			if result.err == nil {
+				result.response, result.err = c.accessSecretVersion(ctx, ref.Name, ref.Version)
+			}
+
+			mu.Lock()
+			results[i] = result
+			if result.err != nil {
+				failed = true
+			}
+			mu.Unlock()
+		}(i, ref)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// jitter waits for a random duration up to BatchJitter, or until ctx is done.
+func (c *Config) jitter(ctx context.Context) error {
+	if c.BatchJitter <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(rand.N(c.BatchJitter))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
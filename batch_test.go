@@ -0,0 +1,91 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// concurrencyRecorder records the highest number of concurrent accesses.
+type concurrencyRecorder struct {
+	mockSecretManagerClient
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (m *concurrencyRecorder) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	m.mu.Lock()
+	m.inFlight++
+	if m.inFlight > m.maxInFlight {
+		m.maxInFlight = m.inFlight
+	}
+	m.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	m.mu.Lock()
+	m.inFlight--
+	m.mu.Unlock()
+
+	// Each secret sets a variable named after it
+	name := strings.Split(req.Name, "/")[3]
+	return &secretmanagerpb.AccessSecretVersionResponse{
+		Payload: &secretmanagerpb.SecretPayload{Data: []byte("BATCH_" + strings.ToUpper(name) + "=" + name)},
+	}, nil
+}
+
+func TestLoadAllSecretsToEnvMaxConcurrency(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f"}
+
+	testCases := []struct {
+		name           string
+		maxConcurrency int
+		expectedMax    int
+	}{
+		{name: "sequential by default", expectedMax: 1},
+		{name: "bounded concurrency", maxConcurrency: 2, expectedMax: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range names {
+				t.Setenv("BATCH_"+strings.ToUpper(name), "")
+			}
+
+			recorder := &concurrencyRecorder{}
+			c := &Client{
+				client: recorder,
+				config: &Config{
+					ProjectID:      "p",
+					SecretNames:    names,
+					SecretVersion:  LatestVersion,
+					MaxConcurrency: tc.maxConcurrency,
+					BatchJitter:    time.Millisecond,
+				},
+			}
+
+			assert.NoError(t, c.LoadAllSecretsToEnv(context.Background()))
+			assert.LessOrEqual(t, recorder.maxInFlight, tc.expectedMax)
+			for _, name := range names {
+				assert.Equal(t, name, os.Getenv("BATCH_"+strings.ToUpper(name)))
+			}
+		})
+	}
+}
+
+func TestConfigJitter(t *testing.T) {
+	assert.NoError(t, (&Config{}).jitter(context.Background()))
+	assert.NoError(t, (&Config{BatchJitter: time.Millisecond}).jitter(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, (&Config{BatchJitter: time.Hour}).jitter(ctx), context.Canceled)
+}
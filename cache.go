@@ -0,0 +1,67 @@
+package GCPSecretManager
+
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+// timeNow returns the current time, replaced in tests.
+var timeNow = time.Now
+
+// secretCache holds the last retrieved payload of the configured secret.
+type secretCache struct {
+	mu        sync.Mutex
+	data      []byte
+	expiresAt time.Time
+}
+
+// get returns a copy of the cached payload if it has not expired.
+func (s *secretCache) get() ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data == nil || !timeNow().Before(s.expiresAt) {
+		return nil, false
+	}
+	return bytes.Clone(s.data), true
+}
+
+// stale returns a copy of the cached payload, even if it has expired.
+func (s *secretCache) stale() ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data == nil {
+		return nil, false
+	}
+	return bytes.Clone(s.data), true
+}
+
+// set stores a copy of data until ttl elapses.
+func (s *secretCache) set(data []byte, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = bytes.Clone(data)
+	if s.data == nil {
+		s.data = []byte{}
+	}
+	s.expiresAt = timeNow().Add(ttl)
+}
+
+// clear drops the cached payload.
+func (s *secretCache) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = nil
+	s.expiresAt = time.Time{}
+}
+
+// InvalidateCache drops the cached secret value, so the next call to
+// GetSecret or GetSecretBytes fetches it from Secret Manager again. It is
+// useful after a known rotation and is a no-op when caching is disabled.
+func (c *Client) InvalidateCache() {
+	c.cache.clear()
+}
@@ -0,0 +1,76 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSecretCache(t *testing.T) {
+	originalTimeNow := timeNow
+	defer func() { timeNow = originalTimeNow }()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	ctx := context.Background()
+	mock := &sequenceMockClient{payloads: []string{"v1", "v2", "v3"}}
+	c := &Client{client: mock, config: &Config{CacheTTL: time.Minute}}
+
+	// Within the TTL the API is called once
+	for i := 0; i < 3; i++ {
+		secret, err := c.GetSecret(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", secret)
+	}
+	assert.Len(t, mock.names, 1)
+
+	// Once the TTL elapses the value is fetched again
+	now = now.Add(time.Minute)
+	secret, err := c.GetSecret(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", secret)
+	assert.Len(t, mock.names, 2)
+
+	// Invalidating forces a refresh
+	c.InvalidateCache()
+	secret, err = c.GetSecret(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "v3", secret)
+	assert.Len(t, mock.names, 3)
+}
+
+func TestGetSecretCacheDisabled(t *testing.T) {
+	mock := &sequenceMockClient{payloads: []string{"v1"}}
+	c := &Client{client: mock, config: &Config{}}
+
+	for i := 0; i < 3; i++ {
+		_, err := c.GetSecret(context.Background())
+		assert.NoError(t, err)
+	}
+	assert.Len(t, mock.names, 3)
+}
+
+func TestGetSecretCacheConcurrent(t *testing.T) {
+	mock := &sequenceMockClient{payloads: []string{"v1"}}
+	c := &Client{client: mock, config: &Config{CacheTTL: time.Hour}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			data, err := c.GetSecretBytes(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("v1"), data)
+		}()
+		go func() {
+			defer wg.Done()
+			c.InvalidateCache()
+		}()
+	}
+	wg.Wait()
+}
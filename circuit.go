@@ -0,0 +1,129 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrCircuitOpen is returned without calling Secret Manager while the circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("secret manager circuit breaker is open")
+
+// CircuitBreakerConfig stops calling Secret Manager during an outage, so
+// retrying clients do not add load to a struggling service. The zero value
+// disables the breaker.
+//
+// The breaker counts failed secret accesses, each including all of its
+// retries as configured by Config.Retry, so a single access never trips it
+// by itself. Only transient errors, those that would be retried, count as
+// failures; a missing secret or a denied permission does not open the
+// circuit, and an access cancelled or timed out by its caller's context
+// neither counts nor resets the failures. After FailureThreshold
+// consecutive failures the circuit opens and every access fails with
+// ErrCircuitOpen for Cooldown. The next access is then let through as a
+// probe while the others keep failing fast: the circuit closes if the probe
+// succeeds and opens again for Cooldown if it fails.
+//
+// When ServeStale is set together with Config.CacheTTL, GetSecret and
+// GetSecretBytes return the last cached value, even if expired, instead of
+// ErrCircuitOpen while the circuit is open.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed accesses that
+	// opens the circuit. Values below 1 disable the breaker.
+	FailureThreshold int
+	// Cooldown is how long the circuit stays open before a probe is allowed.
+	// Defaults to 30 seconds when zero.
+	Cooldown time.Duration
+	// ServeStale serves the last cached value while the circuit is open.
+	ServeStale bool
+}
+
+// defaultBreakerCooldown is the open duration used when
+// CircuitBreakerConfig.Cooldown is zero.
+const defaultBreakerCooldown = 30 * time.Second
+
+// enabled reports whether the breaker is configured.
+func (b CircuitBreakerConfig) enabled() bool {
+	return b.FailureThreshold > 0
+}
+
+// cooldown returns the configured open duration, or the default.
+func (b CircuitBreakerConfig) cooldown() time.Duration {
+	if b.Cooldown <= 0 {
+		return defaultBreakerCooldown
+	}
+	return b.Cooldown
+}
+
+// circuitBreaker tracks the consecutive failures of a Client.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// allow reports whether an access may call Secret Manager. Once the cooldown
+// has elapsed, a single caller is allowed through as the probe.
+func (b *circuitBreaker) allow(cfg CircuitBreakerConfig) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < cfg.FailureThreshold {
+		return true
+	}
+	if b.probing || timeNow().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record updates the breaker with the outcome of an allowed access, where
+// err is the error of its last attempt. Only a success closes an open
+// circuit: any other outcome of a probe that is not a transient failure
+// leaves the circuit open for the next probe.
+func (b *circuitBreaker) record(cfg CircuitBreakerConfig, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbing := b.probing
+	b.probing = false
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	if !isRetryable(err) {
+		if !wasProbing && !isCallerCancellation(err) {
+			b.failures = 0
+		}
+		return
+	}
+
+	b.failures++
+	if b.failures >= cfg.FailureThreshold {
+		b.openUntil = timeNow().Add(cfg.cooldown())
+	}
+}
+
+// release ends an allowed access without recording its outcome, so that
+// another access may probe the circuit.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// isCallerCancellation reports whether err comes from the caller's context
+// being cancelled or expiring rather than from Secret Manager.
+func isCallerCancellation(err error) bool {
+	return errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		status.Code(err) == codes.Canceled
+}
@@ -0,0 +1,255 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	originalTimeNow := timeNow
+	defer func() { timeNow = originalTimeNow }()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	ctx := context.Background()
+	unavailable := status.Error(codes.Unavailable, "down")
+	mock := &mockSecretManagerClient{
+		secretPayload: "value",
+		isSuccess:     true,
+		errs:          []error{unavailable, unavailable, unavailable},
+	}
+	c := &Client{client: mock, config: &Config{
+		CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Minute},
+	}}
+
+	// Failures below the threshold reach the API
+	for i := 0; i < 2; i++ {
+		_, err := c.GetSecret(ctx)
+		assert.ErrorIs(t, err, ErrUnavailable)
+	}
+	assert.Equal(t, 2, mock.calls)
+
+	// The open circuit fails fast
+	_, err := c.GetSecret(ctx)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, mock.calls)
+
+	// A failed probe after the cooldown opens the circuit again
+	now = now.Add(time.Minute)
+	_, err = c.GetSecret(ctx)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, 3, mock.calls)
+	_, err = c.GetSecret(ctx)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, mock.calls)
+
+	// A successful probe closes the circuit
+	now = now.Add(time.Minute)
+	for i := 0; i < 2; i++ {
+		secret, err := c.GetSecret(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "value", secret)
+	}
+	assert.Equal(t, 5, mock.calls)
+}
+
+func TestCircuitBreakerIgnoresPermanentErrors(t *testing.T) {
+	mock := &mockSecretManagerClient{errs: []error{
+		status.Error(codes.NotFound, "missing"),
+		status.Error(codes.NotFound, "missing"),
+		status.Error(codes.NotFound, "missing"),
+	}}
+	c := &Client{client: mock, config: &Config{
+		CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 1},
+	}}
+
+	for i := 0; i < 3; i++ {
+		_, err := c.GetSecret(context.Background())
+		assert.ErrorIs(t, err, ErrSecretNotFound)
+	}
+	assert.Equal(t, 3, mock.calls)
+}
+
+func TestCircuitBreakerCountsRetriedAccessOnce(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "down")
+	mock := &mockSecretManagerClient{
+		secretPayload: "value",
+		isSuccess:     true,
+		errs:          []error{unavailable, unavailable},
+	}
+	c := &Client{client: mock, config: &Config{
+		Retry:          RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond},
+		CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 1},
+	}}
+
+	secret, err := c.GetSecret(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "value", secret)
+	assert.Equal(t, 3, mock.calls)
+}
+
+func TestCircuitBreakerServeStale(t *testing.T) {
+	originalTimeNow := timeNow
+	defer func() { timeNow = originalTimeNow }()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	testCases := []struct {
+		name        string
+		serveStale  bool
+		expected    string
+		expectedErr error
+	}{
+		{name: "stale value served", serveStale: true, expected: "cached"},
+		{name: "stale value disabled", expectedErr: ErrCircuitOpen},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			unavailable := status.Error(codes.Unavailable, "down")
+			mock := &mockSecretManagerClient{
+				secretPayload: "cached",
+				isSuccess:     true,
+				errs:          []error{nil, unavailable},
+			}
+			c := &Client{client: mock, config: &Config{
+				CacheTTL:       time.Minute,
+				CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 1, ServeStale: tc.serveStale},
+			}}
+
+			_, err := c.GetSecret(context.Background())
+			assert.NoError(t, err)
+
+			// The cache expires and the refresh fails, opening the circuit
+			now = now.Add(time.Minute)
+			_, err = c.GetSecret(context.Background())
+			assert.ErrorIs(t, err, ErrUnavailable)
+
+			secret, err := c.GetSecret(context.Background())
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, secret)
+			assert.Equal(t, 2, mock.calls)
+		})
+	}
+}
+
+func TestCircuitBreakerOpensWhenDeadlineInterruptsRetries(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "down")
+	mock := &mockSecretManagerClient{errs: []error{unavailable, unavailable, unavailable, unavailable}}
+	c := &Client{client: mock, config: &Config{
+		Retry:          RetryConfig{MaxAttempts: 3, BaseDelay: time.Second},
+		CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Minute},
+	}}
+
+	for i := 0; i < 4; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := c.GetSecret(ctx)
+		cancel()
+		if i < 2 {
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.ErrorIs(t, err, ErrUnavailable)
+		} else {
+			assert.ErrorIs(t, err, ErrCircuitOpen)
+		}
+	}
+	assert.Equal(t, 2, mock.calls)
+}
+
+func TestCircuitBreakerProbeOutcome(t *testing.T) {
+	originalTimeNow := timeNow
+	defer func() { timeNow = originalTimeNow }()
+
+	testCases := []struct {
+		name        string
+		probeErr    error
+		expectedErr error
+		closed      bool
+	}{
+		{name: "not found probe keeps circuit open", probeErr: status.Error(codes.NotFound, "missing"), expectedErr: ErrSecretNotFound},
+		{name: "cancelled probe keeps circuit open", probeErr: status.Error(codes.Canceled, "cancelled")},
+		{name: "context error probe keeps circuit open", probeErr: context.DeadlineExceeded, expectedErr: context.DeadlineExceeded},
+		{name: "successful probe closes circuit", closed: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			timeNow = func() time.Time { return now }
+
+			mock := &mockSecretManagerClient{
+				secretPayload: "value",
+				isSuccess:     true,
+				errs:          []error{status.Error(codes.Unavailable, "down"), tc.probeErr},
+			}
+			c := &Client{client: mock, config: &Config{
+				CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute},
+			}}
+
+			_, err := c.GetSecret(context.Background())
+			assert.ErrorIs(t, err, ErrUnavailable)
+
+			// The probe runs once the cooldown has elapsed
+			now = now.Add(time.Minute)
+			_, err = c.GetSecret(context.Background())
+			if tc.probeErr == nil {
+				assert.NoError(t, err)
+			} else if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.Error(t, err)
+			}
+			assert.Equal(t, 2, mock.calls)
+
+			// Only a successful probe closes the circuit; otherwise the
+			// failure count is kept and the next access is a new probe
+			c.breaker.mu.Lock()
+			failures := c.breaker.failures
+			c.breaker.mu.Unlock()
+			if tc.closed {
+				assert.Equal(t, 0, failures)
+			} else {
+				assert.Equal(t, 1, failures)
+			}
+		})
+	}
+}
+
+// expiringMockClient waits for the caller's context to end and then fails
+// like a real gRPC call cut off by its deadline.
+type expiringMockClient struct {
+	mockSecretManagerClient
+}
+
+func (m *expiringMockClient) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	m.calls++
+	<-ctx.Done()
+	return nil, status.Error(codes.DeadlineExceeded, "context deadline exceeded")
+}
+
+func TestCircuitBreakerIgnoresCallerDeadline(t *testing.T) {
+	mock := &expiringMockClient{}
+	c := &Client{client: mock, config: &Config{
+		CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute},
+	}}
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		_, err := c.GetSecret(ctx)
+		cancel()
+		assert.NotErrorIs(t, err, ErrCircuitOpen)
+	}
+	assert.Equal(t, 3, mock.calls)
+}
@@ -0,0 +1,49 @@
+package GCPSecretManager_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	GCPSecretManager "github.com/TTEC-Engage-Digital/GCPSecretManager"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClient struct {
+	payload string
+	names   []string
+}
+
+func (f *fakeClient) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	f.names = append(f.names, req.Name)
+	return &secretmanagerpb.AccessSecretVersionResponse{
+		Name:    req.Name,
+		Payload: &secretmanagerpb.SecretPayload{Data: []byte(f.payload)},
+	}, nil
+}
+
+func (f *fakeClient) GetSecretVersion(ctx context.Context, req *secretmanagerpb.GetSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	return &secretmanagerpb.SecretVersion{Name: req.Name}, nil
+}
+
+func (f *fakeClient) Close() error {
+	return nil
+}
+
+func TestNewClient(t *testing.T) {
+	t.Setenv(GCPSecretManager.LocalFileEnv, "/should/not/be/read")
+	t.Setenv("NEW_CLIENT_KEY", "")
+
+	fake := &fakeClient{payload: "NEW_CLIENT_KEY=value\n"}
+	cfg := &GCPSecretManager.Config{ProjectID: "p", SecretName: "s"}
+	client := GCPSecretManager.NewClient(fake, cfg)
+	cfg.SecretName = "changed"
+
+	ctx := context.Background()
+	assert.NoError(t, client.LoadSecretToEnv(ctx))
+	assert.Equal(t, "value", os.Getenv("NEW_CLIENT_KEY"))
+	assert.Equal(t, []string{"projects/p/secrets/s/versions/latest"}, fake.names)
+	assert.NoError(t, client.Close())
+}
@@ -0,0 +1,47 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClientConcurrentUse is meant to be run with -race.
+func TestClientConcurrentUse(t *testing.T) {
+	mock := &sequenceMockClient{payloads: []string{"CONCURRENT=1"}}
+	c := &Client{client: mock, config: &Config{ProjectID: "p", SecretName: "s", SecretVersion: "latest"}}
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			secret, err := c.GetSecret(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, "CONCURRENT=1", secret)
+		}()
+		go func() {
+			defer wg.Done()
+			values, err := c.GetSecretAsMap(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]string{"CONCURRENT": "1"}, values)
+		}()
+	}
+	wg.Wait()
+
+	wg.Add(2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.Close())
+		}()
+	}
+	wg.Wait()
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	assert.Len(t, mock.names, 100)
+}
@@ -0,0 +1,71 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DiffVersions compares two versions of the configured secret and reports
+// which keys changed between them, for example to review a rotation. Only
+// key names are returned, never values.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - a: The old version, for example the outgoing one.
+// - b: The new version, for example the incoming one.
+//
+// Returns:
+// - added: The keys present in b but not in a, sorted.
+// - removed: The keys present in a but not in b, sorted.
+// - changed: The keys present in both whose values differ, sorted.
+// - err: An error if either version is invalid, cannot be retrieved, or
+// cannot be parsed.
+func (c *Client) DiffVersions(ctx context.Context, a, b string) (added, removed, changed []string, err error) {
+	before, err := c.versionValues(ctx, a)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	after, err := c.versionValues(ctx, b)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for key, value := range after {
+		previous, ok := before[key]
+		switch {
+		case !ok:
+			added = append(added, key)
+		case previous != value:
+			changed = append(changed, key)
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return added, removed, changed, nil
+}
+
+// versionValues retrieves and parses the given version of the configured secret.
+func (c *Client) versionValues(ctx context.Context, version string) (map[string]string, error) {
+	content, err := c.GetSecretVersion(ctx, version)
+	if err != nil {
+		if errors.Is(err, ErrInvalidVersion) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("version %s: failed to retrieve secret: %w", version, err)
+	}
+
+	values, err := c.config.parseSecret(content, nil)
+	if err != nil {
+		return nil, fmt.Errorf("version %s: failed to parse secret: %w", version, err)
+	}
+	return values, nil
+}
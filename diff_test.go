@@ -0,0 +1,73 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffVersions(t *testing.T) {
+	const prefix = "projects/p/secrets/s/versions/"
+	payloads := map[string]string{
+		prefix + "1": "KEEP=same\nCHANGE=old\nREMOVE=gone\n",
+		prefix + "2": "KEEP=same\nCHANGE=new\nADD_B=x\nADD_A=y\n",
+		prefix + "3": "broken\n",
+	}
+
+	testCases := []struct {
+		name            string
+		a, b            string
+		expectedAdded   []string
+		expectedRemoved []string
+		expectedChanged []string
+		expectedErr     string
+	}{
+		{
+			name:            "every category",
+			a:               "1",
+			b:               "2",
+			expectedAdded:   []string{"ADD_A", "ADD_B"},
+			expectedRemoved: []string{"REMOVE"},
+			expectedChanged: []string{"CHANGE"},
+		},
+		{
+			name:            "reversed",
+			a:               "2",
+			b:               "1",
+			expectedAdded:   []string{"REMOVE"},
+			expectedRemoved: []string{"ADD_A", "ADD_B"},
+			expectedChanged: []string{"CHANGE"},
+		},
+		{name: "identical", a: "1", b: "1"},
+		{name: "missing version", a: "1", b: "9", expectedErr: "version 9: failed to retrieve secret"},
+		{name: "malformed version", a: "3", b: "1", expectedErr: "version 3: failed to parse secret"},
+		{name: "invalid version", a: "1", b: "a/b", expectedErr: "invalid secret version"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: &versionPayloadClient{payloads: payloads},
+				config: &Config{ProjectID: "p", SecretName: "s"},
+			}
+
+			added, removed, changed, err := c.DiffVersions(context.Background(), tc.a, tc.b)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedAdded, added)
+			assert.Equal(t, tc.expectedRemoved, removed)
+			assert.Equal(t, tc.expectedChanged, changed)
+
+			// Values never appear in the result
+			all := strings.Join(append(append(added, removed...), changed...), ",")
+			for _, value := range []string{"same", "old", "new", "gone", "x", "y"} {
+				assert.NotContains(t, strings.Split(all, ","), value)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package GCPSecretManager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dotenvEscaper escapes the characters interpreted inside double-quoted values.
+var dotenvEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// WriteSecretToFile retrieves the secret, parses it with the same rules and
+// transforms as LoadSecretToEnv, and writes the resulting variables to path
+// in dotenv format, one KEY=VALUE line per variable. Values that would not
+// survive a round trip unquoted are written double-quoted and escaped.
+//
+// The file is written to a temporary file in the same directory and renamed
+// into place, so a crash never leaves a partial file. Since the file holds
+// secret values, a restrictive permission such as 0600 is recommended.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - path: The destination file.
+// - perm: The permission bits of the destination file.
+//
+// Returns:
+// - An error if the secret retrieval, parsing, or file writing fails.
+func (c *Client) WriteSecretToFile(ctx context.Context, path string, perm os.FileMode) error {
+	entries, err := c.getResolvedEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		fmt.Fprintf(&buf, "%s=%s\n", entry.Key, formatDotenvValue(entry.Value))
+	}
+
+	if err := writeFileAtomic(path, buf.Bytes(), perm); err != nil {
+		return fmt.Errorf("failed to write secret file: %w", err)
+	}
+	return nil
+}
+
+// getResolvedEntries retrieves and parses the secret, and returns the
+// entries that would be set as environment variables, in order. Any
+// malformed line is an error, even when ContinueOnError is set.
+func (c *Client) getResolvedEntries(ctx context.Context) ([]secretEntry, error) {
+	entries, err := c.getSecretEntries(ctx, c.config, nil)
+	if err != nil {
+		var parseErr ParseError
+		if errors.As(err, &parseErr) {
+			return nil, fmt.Errorf("failed to parse secret: %w", err)
+		}
+		return nil, err
+	}
+
+	return c.config.resolveEntries(entries), nil
+}
+
+// resolveEntries returns the entries that would be set as environment
+// variables: keys rejected by KeyFilter are dropped, KeyPrefix is applied,
+// and a key repeated in the secret keeps its first position with its last
+// value.
+func (c *Config) resolveEntries(entries []secretEntry) []secretEntry {
+	resolved := make([]secretEntry, 0, len(entries))
+	index := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		if !c.keep(entry.Key) {
+			continue
+		}
+		entry.Key = c.KeyPrefix + entry.Key
+
+		if i, seen := index[entry.Key]; seen {
+			resolved[i].Value = entry.Value
+			continue
+		}
+		index[entry.Key] = len(resolved)
+		resolved = append(resolved, entry)
+	}
+	return resolved
+}
+
+// formatDotenvValue returns value as written in a dotenv file, double-quoted
+// and escaped when it contains whitespace, quotes or characters with a
+// special meaning to the parser.
+func formatDotenvValue(value string) string {
+	if !strings.ContainsAny(value, " \t\r\n\"'\\#=[]") {
+		return value
+	}
+	return `"` + dotenvEscaper.Replace(value) + `"`
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it
+// over path once it is fully written.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
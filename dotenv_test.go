@@ -0,0 +1,66 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteSecretToFile(t *testing.T) {
+	payload := "# comment\nPLAIN=value\nSPACED=\"  padded  \"\nMULTI=\"a\\nb\"\nEQUALS=a=b\nPLAIN=override\n"
+
+	c := &Client{
+		client: &mockSecretManagerClient{
+			secretPayload: payload,
+			isSuccess:     true,
+		},
+		config: &Config{KeyPrefix: "APP_"},
+	}
+
+	path := filepath.Join(t.TempDir(), ".env")
+	err := c.WriteSecretToFile(context.Background(), path, 0o600)
+	assert.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "APP_PLAIN=override\nAPP_SPACED=\"  padded  \"\nAPP_MULTI=\"a\\nb\"\nAPP_EQUALS=\"a=b\"\n", string(data))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// The written file parses back to the same values
+	values, err := (&Config{}).parseSecret(string(data), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"APP_PLAIN":  "override",
+		"APP_SPACED": "  padded  ",
+		"APP_MULTI":  "a\nb",
+		"APP_EQUALS": "a=b",
+	}, values)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestWriteSecretToFileErrors(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), ".env")
+
+	c := &Client{client: &mockSecretManagerClient{isSuccess: false}, config: &Config{}}
+	assert.ErrorContains(t, c.WriteSecretToFile(ctx, path, 0o600), "failed to retrieve secret")
+
+	c = &Client{client: &mockSecretManagerClient{secretPayload: "broken", isSuccess: true}, config: &Config{}}
+	assert.ErrorContains(t, c.WriteSecretToFile(ctx, path, 0o600), "failed to parse secret")
+
+	c = &Client{client: &mockSecretManagerClient{secretPayload: "A=1", isSuccess: true}, config: &Config{}}
+	missingDir := filepath.Join(t.TempDir(), "missing", ".env")
+	assert.ErrorContains(t, c.WriteSecretToFile(ctx, missingDir, 0o600), "failed to write secret file")
+
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
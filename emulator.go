@@ -0,0 +1,29 @@
+package GCPSecretManager
+
+import (
+	"os"
+
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// EmulatorHostEnv is the environment variable holding the host:port of a
+// Secret Manager emulator. When it is unset or empty, the real API is used.
+const EmulatorHostEnv = "SECRET_MANAGER_EMULATOR_HOST"
+
+// emulatorOptions returns the client options connecting to the emulator
+// named by SECRET_MANAGER_EMULATOR_HOST without TLS or authentication, or nil
+// when the variable is not set.
+func emulatorOptions() []option.ClientOption {
+	host := os.Getenv(EmulatorHostEnv)
+	if host == "" {
+		return nil
+	}
+
+	return []option.ClientOption{
+		option.WithEndpoint(host),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	}
+}
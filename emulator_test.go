@@ -0,0 +1,25 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmulatorOptions(t *testing.T) {
+	t.Setenv(EmulatorHostEnv, "")
+	assert.Nil(t, emulatorOptions())
+
+	t.Setenv(EmulatorHostEnv, "localhost:9090")
+	assert.Len(t, emulatorOptions(), 3)
+}
+
+func TestDefaultClientFactoryWithEmulator(t *testing.T) {
+	t.Setenv(EmulatorHostEnv, "localhost:9090")
+
+	// Creating the client does not dial, so no emulator needs to be running
+	client, err := defaultClientFactory(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, client.Close())
+}
@@ -0,0 +1,65 @@
+package GCPSecretManager
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrSecretNotFound is returned when the secret or version does not exist.
+	ErrSecretNotFound = errors.New("secret not found")
+	// ErrPermissionDenied is returned when the caller is not allowed to access the secret.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrUnauthenticated is returned when the request has no valid credentials.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrUnavailable is returned when Secret Manager is temporarily unavailable.
+	ErrUnavailable = errors.New("secret manager unavailable")
+	// ErrVersionDisabled is returned when the version exists but is disabled.
+	// It can be enabled again with Client.EnableSecretVersion.
+	ErrVersionDisabled = errors.New("secret version disabled")
+)
+
+// statusErrors maps gRPC status codes to the sentinel errors returned to callers.
+var statusErrors = map[codes.Code]error{
+	codes.NotFound:         ErrSecretNotFound,
+	codes.PermissionDenied: ErrPermissionDenied,
+	codes.Unauthenticated:  ErrUnauthenticated,
+	codes.Unavailable:      ErrUnavailable,
+}
+
+// classifyError wraps err with the sentinel error matching its gRPC status
+// code, so that callers can use errors.Is while the original error, and its
+// status details, remain available through errors.As.
+//
+// Parameters:
+// - err: The error returned by the Secret Manager API.
+//
+// Returns:
+// - err wrapped with the matching sentinel, ErrVersionDisabled for the
+// FailedPrecondition of a disabled version, or err unchanged if its code has
+// no sentinel.
+func classifyError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	if sentinel, found := statusErrors[st.Code()]; found {
+		return fmt.Errorf("%w: %w", sentinel, err)
+	}
+	if isVersionDisabled(st) {
+		return fmt.Errorf("%w: %w", ErrVersionDisabled, err)
+	}
+	return err
+}
+
+// isVersionDisabled reports whether st is the FailedPrecondition status
+// returned when accessing a disabled version, which Secret Manager words as
+// "Secret Version [NAME] is in DISABLED state." Other failed preconditions,
+// such as a destroyed version, are not matched.
+func isVersionDisabled(st *status.Status) bool {
+	return st.Code() == codes.FailedPrecondition && strings.Contains(st.Message(), "DISABLED state")
+}
@@ -0,0 +1,171 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetSecretTypedErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		sentinel error
+	}{
+		{name: "not found", err: status.Error(codes.NotFound, "missing"), sentinel: ErrSecretNotFound},
+		{name: "permission denied", err: status.Error(codes.PermissionDenied, "denied"), sentinel: ErrPermissionDenied},
+		{name: "unauthenticated", err: status.Error(codes.Unauthenticated, "no token"), sentinel: ErrUnauthenticated},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "down"), sentinel: ErrUnavailable},
+		{
+			name:     "disabled version",
+			err:      status.Error(codes.FailedPrecondition, "Secret Version [projects/p/secrets/s/versions/3] is in DISABLED state."),
+			sentinel: ErrVersionDisabled,
+		},
+		{name: "destroyed version", err: status.Error(codes.FailedPrecondition, "Secret Version [projects/p/secrets/s/versions/2] is in DESTROYED state.")},
+		{name: "other status code", err: status.Error(codes.InvalidArgument, "bad")},
+		{name: "not a status error", err: fmt.Errorf("plain failure")},
+	}
+
+	sentinels := []error{ErrSecretNotFound, ErrPermissionDenied, ErrUnauthenticated, ErrUnavailable, ErrVersionDisabled}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: &mockSecretManagerClient{errs: []error{tc.err}},
+				config: &Config{},
+			}
+
+			_, err := c.GetSecret(context.Background())
+			assert.Error(t, err)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Contains(t, err.Error(), tc.err.Error())
+			assert.Equal(t, status.Code(tc.err), status.Code(err))
+
+			for _, sentinel := range sentinels {
+				assert.Equal(t, sentinel == tc.sentinel, errors.Is(err, sentinel), sentinel.Error())
+			}
+		})
+	}
+}
+
+func TestGetSecretOrDefault(t *testing.T) {
+	testCases := []struct {
+		name     string
+		client   *mockSecretManagerClient
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "secret found",
+			client:   &mockSecretManagerClient{secretPayload: "value", isSuccess: true},
+			expected: "value",
+		},
+		{
+			name:     "secret not found",
+			client:   &mockSecretManagerClient{errs: []error{status.Error(codes.NotFound, "missing")}},
+			expected: "fallback",
+		},
+		{
+			name:    "permission denied",
+			client:  &mockSecretManagerClient{errs: []error{status.Error(codes.PermissionDenied, "denied")}},
+			wantErr: ErrPermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{client: tc.client, config: &Config{}}
+
+			value, err := c.GetSecretOrDefault(context.Background(), "fallback")
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, value)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestGetSecretErrorName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{
+			name:     "full resource name",
+			config:   Config{ProjectID: "p", SecretName: "s", SecretVersion: "3"},
+			expected: "failed to access secret projects/p/secrets/s/versions/3: access error",
+		},
+		{
+			name:     "regional resource name",
+			config:   Config{ProjectID: "p", Location: "europe-west4", SecretName: "s", SecretVersion: "latest"},
+			expected: "failed to access secret projects/p/locations/europe-west4/secrets/s/versions/latest: access error",
+		},
+		{
+			name:     "project omitted",
+			config:   Config{ProjectID: "p", SecretName: "s", SecretVersion: "3", OmitProjectInErrors: true},
+			expected: "failed to access secret secrets/s/versions/3: access error",
+		},
+		{
+			name:     "project omitted from regional resource name",
+			config:   Config{ProjectID: "p", Location: "europe-west4", SecretName: "s", SecretVersion: "3", OmitProjectInErrors: true},
+			expected: "failed to access secret locations/europe-west4/secrets/s/versions/3: access error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{client: &mockSecretManagerClient{}, config: &tc.config}
+
+			_, err := c.GetSecret(context.Background())
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestGetSecretPayloadErrorName(t *testing.T) {
+	invalid := int64(1)
+	testCases := []struct {
+		name     string
+		mock     *mockSecretManagerClient
+		config   Config
+		expected string
+	}{
+		{
+			name:     "checksum mismatch",
+			mock:     &mockSecretManagerClient{secretPayload: "value", isSuccess: true, crc32c: &invalid},
+			config:   Config{ProjectID: "p", SecretName: "s", SecretVersion: "3", OmitProjectInErrors: true},
+			expected: "failed to access secret secrets/s/versions/3: secret payload checksum mismatch",
+		},
+		{
+			name:     "empty secret",
+			mock:     &mockSecretManagerClient{isSuccess: true},
+			config:   Config{ProjectID: "p", SecretName: "s", SecretVersion: "3", OmitProjectInErrors: true, RejectEmptySecret: true},
+			expected: "failed to access secret secrets/s/versions/3: secret payload is empty",
+		},
+		{
+			name:     "invalid gzip payload",
+			mock:     &mockSecretManagerClient{secretPayload: "\x1f\x8bnot gzip", isSuccess: true},
+			config:   Config{ProjectID: "p", SecretName: "s", SecretVersion: "3", OmitProjectInErrors: true, DecompressGzip: true},
+			expected: "failed to access secret secrets/s/versions/3: failed to decompress gzip secret payload",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{client: tc.mock, config: &tc.config}
+
+			_, err := c.GetSecret(context.Background())
+			assert.ErrorContains(t, err, tc.expected)
+			assert.NotContains(t, err.Error(), "projects/p")
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// secretGetterClient is implemented by clients that can read the metadata of
+// a secret without accessing any of its versions.
+type secretGetterClient interface {
+	GetSecret(ctx context.Context, req *secretmanagerpb.GetSecretRequest, opts ...gax.CallOption) (*secretmanagerpb.Secret, error)
+}
+
+var _ secretGetterClient = (*secretmanager.Client)(nil)
+
+// SecretExists reports whether the configured secret exists. It reads the
+// secret metadata when the client supports it, and otherwise the metadata of
+// the configured version, without accessing the payload.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - false and a nil error if the API reports the secret as NotFound.
+// - true and a nil error if the secret exists.
+// - false and the underlying error for any other failure, such as
+// PermissionDenied, so that it is not mistaken for a missing secret.
+func (c *Client) SecretExists(ctx context.Context) (bool, error) {
+	err := withRetry(ctx, c.config.Retry, func() error {
+		// Add a timeout to the context to limit the duration of each API call
+		callCtx, cancel := c.config.callContext(ctx)
+		defer cancel()
+
+		if getter, ok := c.client.(secretGetterClient); ok {
+			_, err := getter.GetSecret(callCtx, &secretmanagerpb.GetSecretRequest{
+				Name: c.config.secretPath(c.config.SecretName),
+			})
+			return err
+		}
+
+		_, err := c.client.GetSecretVersion(callCtx, &secretmanagerpb.GetSecretVersionRequest{
+			Name: c.config.versionPath(c.config.SecretName, c.config.SecretVersion),
+		})
+		return err
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to check secret existence: %w", classifyError(err))
+}
@@ -0,0 +1,110 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type secretGetterMockClient struct {
+	mockSecretManagerClient
+	getErr error
+	secret *secretmanagerpb.Secret
+	names  []string
+}
+
+func (m *secretGetterMockClient) GetSecret(ctx context.Context, req *secretmanagerpb.GetSecretRequest, opts ...gax.CallOption) (*secretmanagerpb.Secret, error) {
+	m.names = append(m.names, req.Name)
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	if m.secret != nil {
+		return m.secret, nil
+	}
+	return &secretmanagerpb.Secret{Name: req.Name}, nil
+}
+
+func TestSecretExists(t *testing.T) {
+	testCases := []struct {
+		name     string
+		client   SecretManagerClient
+		expected bool
+		wantErr  codes.Code
+	}{
+		{
+			name:     "metadata found",
+			client:   &secretGetterMockClient{},
+			expected: true,
+		},
+		{
+			name:   "metadata not found",
+			client: &secretGetterMockClient{getErr: status.Error(codes.NotFound, "secret not found")},
+		},
+		{
+			name:    "metadata permission denied",
+			client:  &secretGetterMockClient{getErr: status.Error(codes.PermissionDenied, "denied")},
+			wantErr: codes.PermissionDenied,
+		},
+		{
+			name:     "version fallback found",
+			client:   &mockSecretManagerClient{isSuccess: true},
+			expected: true,
+		},
+		{
+			name:   "version fallback not found",
+			client: &mockSecretManagerClient{errs: []error{status.Error(codes.NotFound, "version not found")}},
+		},
+		{
+			name:    "version fallback permission denied",
+			client:  &mockSecretManagerClient{errs: []error{status.Error(codes.PermissionDenied, "denied")}},
+			wantErr: codes.PermissionDenied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: tc.client,
+				config: &Config{ProjectID: "p", SecretName: "s", SecretVersion: LatestVersion},
+			}
+
+			exists, err := c.SecretExists(context.Background())
+			assert.Equal(t, tc.expected, exists)
+			if tc.wantErr != codes.OK {
+				assert.Error(t, err)
+				assert.Equal(t, tc.wantErr, status.Code(err))
+				if tc.wantErr == codes.PermissionDenied {
+					assert.ErrorIs(t, err, ErrPermissionDenied)
+				}
+				return
+			}
+			assert.NoError(t, err)
+
+			if getter, ok := tc.client.(*secretGetterMockClient); ok {
+				assert.Equal(t, []string{"projects/p/secrets/s"}, getter.names)
+			}
+		})
+	}
+}
+
+func TestSecretExistsLocalFile(t *testing.T) {
+	c := &Client{
+		client: &localFileClient{path: filepath.Join(t.TempDir(), "missing")},
+		config: &Config{},
+	}
+
+	exists, err := c.SecretExists(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	c.client = &localFileClient{path: t.TempDir()}
+	exists, err = c.SecretExists(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
@@ -0,0 +1,50 @@
+package GCPSecretManager
+
+import "context"
+
+// AllowKeys returns a key filter accepting only the given keys.
+func AllowKeys(keys ...string) func(string) bool {
+	allowed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		allowed[key] = true
+	}
+	return func(key string) bool {
+		return allowed[key]
+	}
+}
+
+// DenyKeys returns a key filter rejecting the given keys.
+func DenyKeys(keys ...string) func(string) bool {
+	allow := AllowKeys(keys...)
+	return func(key string) bool {
+		return !allow(key)
+	}
+}
+
+// keep reports whether KeyFilter accepts key.
+func (c *Config) keep(key string) bool {
+	return c.KeyFilter == nil || c.KeyFilter(key)
+}
+
+// LoadSelectedSecretsToEnv works like LoadSecretToEnv but only sets the given
+// keys of the secret, in addition to honoring Config.KeyFilter. Keys missing
+// from the secret are ignored. The secret is loaded like LoadSecretToEnv
+// does, so references, templates and required keys are handled the same way.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - keys: The keys of the secret to set, as seen by KeyFilter, before
+// KeyPrefix is applied.
+//
+// Returns:
+// - An error if the secret retrieval, parsing, or environment variable setting fails.
+func (c *Client) LoadSelectedSecretsToEnv(ctx context.Context, keys []string) error {
+	cfg := *c.config
+	selected := AllowKeys(keys...)
+	cfg.KeyFilter = func(key string) bool {
+		return selected(key) && c.config.keep(key)
+	}
+
+	_, err := c.loadSecretToEnv(ctx, &cfg, nil)
+	return err
+}
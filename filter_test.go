@@ -0,0 +1,127 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyFilters(t *testing.T) {
+	allow := AllowKeys("A", "B")
+	assert.True(t, allow("A"))
+	assert.False(t, allow("C"))
+
+	deny := DenyKeys("A")
+	assert.False(t, deny("A"))
+	assert.True(t, deny("C"))
+}
+
+func TestLoadSecretToEnvKeyFilter(t *testing.T) {
+	payload := "FILTER_A=1\nFILTER_B=2\nFILTER_C=3\n"
+
+	testCases := []struct {
+		name        string
+		filter      func(string) bool
+		selected    []string
+		expectedSet []string
+	}{
+		{name: "allowlist filter", filter: AllowKeys("FILTER_A"), expectedSet: []string{"FILTER_A"}},
+		{name: "denylist filter", filter: DenyKeys("FILTER_A"), expectedSet: []string{"FILTER_B", "FILTER_C"}},
+		{name: "selected keys", selected: []string{"FILTER_B", "FILTER_MISSING"}, expectedSet: []string{"FILTER_B"}},
+		{name: "selected keys and filter", filter: DenyKeys("FILTER_B"), selected: []string{"FILTER_B", "FILTER_C"}, expectedSet: []string{"FILTER_C"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"FILTER_A", "FILTER_B", "FILTER_C"} {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+
+			c := &Client{
+				client: &mockSecretManagerClient{
+					secretPayload: payload,
+					isSuccess:     true,
+				},
+				config: &Config{KeyFilter: tc.filter},
+			}
+
+			var err error
+			if tc.selected != nil {
+				err = c.LoadSelectedSecretsToEnv(context.Background(), tc.selected)
+			} else {
+				err = c.LoadSecretToEnv(context.Background())
+			}
+			assert.NoError(t, err)
+
+			var set []string
+			for _, key := range []string{"FILTER_A", "FILTER_B", "FILTER_C"} {
+				if _, ok := os.LookupEnv(key); ok {
+					set = append(set, key)
+				}
+			}
+			assert.Equal(t, tc.expectedSet, set)
+		})
+	}
+}
+
+func TestLoadSelectedSecretsToEnvPipeline(t *testing.T) {
+	payloads := map[string]string{
+		"projects/p/secrets/shared/versions/latest": "shared-password",
+		"projects/p/secrets/main/versions/latest":   "SELECTED_REF=ref:projects/p/secrets/shared/versions/latest\nSELECTED_OTHER=x\n",
+		"projects/p/secrets/extra/versions/latest":  "SELECTED_EXTRA=y\n",
+	}
+
+	testCases := []struct {
+		name        string
+		config      Config
+		expected    map[string]string
+		missingKeys []string
+	}{
+		{
+			name:     "references resolved",
+			config:   Config{SecretName: "main", ResolveReferences: true},
+			expected: map[string]string{"SELECTED_REF": "shared-password"},
+		},
+		{
+			name:        "required keys checked",
+			config:      Config{SecretName: "main", RequiredKeys: []string{"SELECTED_MISSING"}},
+			missingKeys: []string{"SELECTED_MISSING"},
+		},
+		{
+			name:     "comma-separated secret names merged",
+			config:   Config{SecretName: "main,extra", ResolveReferences: true},
+			expected: map[string]string{"SELECTED_REF": "shared-password", "SELECTED_EXTRA": "y"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"SELECTED_REF", "SELECTED_OTHER", "SELECTED_EXTRA"} {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+
+			tc.config.ProjectID = "p"
+			tc.config.SecretVersion = LatestVersion
+			tc.config.splitSecretName()
+			c := &Client{client: &versionPayloadClient{payloads: payloads}, config: &tc.config}
+
+			err := c.LoadSelectedSecretsToEnv(context.Background(), []string{"SELECTED_REF", "SELECTED_EXTRA"})
+			if tc.missingKeys != nil {
+				var missing MissingKeysError
+				assert.ErrorAs(t, err, &missing)
+				assert.Equal(t, tc.missingKeys, missing.Keys)
+				return
+			}
+			assert.NoError(t, err)
+			for key, value := range tc.expected {
+				assert.Equal(t, value, os.Getenv(key))
+			}
+			_, set := os.LookupEnv("SELECTED_OTHER")
+			assert.False(t, set)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package GCPSecretManager
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrDecompressedTooLarge is returned when a gzip-compressed payload
+// decompresses to more than maxDecompressedSize bytes.
+var ErrDecompressedTooLarge = errors.New("decompressed secret payload is too large")
+
+// gzipMagic are the first bytes of every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// maxDecompressedSize is the largest decompressed payload accepted. Secret
+// Manager payloads are limited to 64 KiB, so 1 MiB leaves room for any
+// realistic compression ratio while stopping a compression bomb from
+// exhausting memory.
+const maxDecompressedSize = 1 << 20
+
+// maybeDecompressGzip decompresses data when it starts with the gzip magic
+// bytes, and returns it unchanged otherwise.
+//
+// Parameters:
+// - data: The raw secret payload.
+//
+// Returns:
+// - The decompressed payload, or data if it is not gzip-compressed.
+// - An error if data starts with the gzip magic bytes but cannot be decompressed.
+// - An error wrapping ErrDecompressedTooLarge if the decompressed payload
+// exceeds maxDecompressedSize.
+func maybeDecompressGzip(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, gzipMagic) {
+		return data, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress gzip secret payload: %w", err)
+	}
+	defer reader.Close()
+
+	// Read one byte past the limit to tell an exact fit from an overflow
+	decompressed, err := io.ReadAll(io.LimitReader(reader, maxDecompressedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress gzip secret payload: %w", err)
+	}
+	if len(decompressed) > maxDecompressedSize {
+		return nil, fmt.Errorf("%w: exceeds %d bytes", ErrDecompressedTooLarge, maxDecompressedSize)
+	}
+	return decompressed, nil
+}
@@ -0,0 +1,93 @@
+package GCPSecretManager
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSecretGzip(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, err := writer.Write([]byte("KEY=value\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	testCases := []struct {
+		name        string
+		config      Config
+		payload     string
+		expected    string
+		expectedErr string
+	}{
+		{name: "compressed payload decompressed", config: Config{DecompressGzip: true}, payload: compressed.String(), expected: "KEY=value\n"},
+		{name: "plain payload returned as is", config: Config{DecompressGzip: true}, payload: "KEY=value\n", expected: "KEY=value\n"},
+		{name: "compressed payload kept when disabled", payload: compressed.String(), expected: compressed.String()},
+		{
+			name:        "corrupted compressed payload",
+			config:      Config{DecompressGzip: true},
+			payload:     "\x1f\x8bnot gzip",
+			expectedErr: "failed to decompress gzip secret payload",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: &mockSecretManagerClient{secretPayload: tc.payload, isSuccess: true},
+				config: &tc.config,
+			}
+
+			content, err := c.GetSecret(context.Background())
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, content)
+		})
+	}
+}
+
+func TestMaybeDecompressGzipSizeLimit(t *testing.T) {
+	compress := func(size int) []byte {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		_, err := writer.Write(bytes.Repeat([]byte("A"), size))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+		return buf.Bytes()
+	}
+
+	testCases := []struct {
+		name        string
+		size        int
+		expectedErr error
+	}{
+		{
+			name: "success at the limit",
+			size: maxDecompressedSize,
+		},
+		{
+			name:        "fail past the limit",
+			size:        maxDecompressedSize + 1,
+			expectedErr: ErrDecompressedTooLarge,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := maybeDecompressGzip(compress(tc.size))
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, data)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, data, tc.size)
+		})
+	}
+}
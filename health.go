@@ -0,0 +1,41 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+)
+
+// Ping checks that Secret Manager is reachable and that the configured
+// secret version can be read, for use in readiness probes. It only reads the
+// version metadata, without retrying, so the payload is neither accessed,
+// parsed, logged nor set in the environment.
+//
+// The deadline of ctx bounds the call, so probes can use a short one. The
+// configured Timeout only applies when ctx has no deadline.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - nil if the secret version metadata could be read.
+// - An error wrapping ErrSecretNotFound, ErrPermissionDenied,
+// ErrUnauthenticated or ErrUnavailable when the gRPC status code matches,
+// or the underlying error otherwise.
+func (c *Client) Ping(ctx context.Context) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = c.config.callContext(ctx)
+		defer cancel()
+	}
+
+	req := &secretmanagerpb.GetSecretVersionRequest{
+		Name: c.config.versionPath(c.config.SecretName, c.config.SecretVersion),
+	}
+	if _, err := c.client.GetSecretVersion(ctx, req); err != nil {
+		return fmt.Errorf("secret manager health check failed: %w", classifyError(err))
+	}
+
+	return nil
+}
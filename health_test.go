@@ -0,0 +1,85 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pingRecorder records the deadline of metadata calls and counts accesses.
+type pingRecorder struct {
+	mockSecretManagerClient
+	deadline    time.Time
+	accessCalls int
+}
+
+func (m *pingRecorder) GetSecretVersion(ctx context.Context, req *secretmanagerpb.GetSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	m.deadline, _ = ctx.Deadline()
+	return m.mockSecretManagerClient.GetSecretVersion(ctx, req, opts...)
+}
+
+func (m *pingRecorder) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	m.accessCalls++
+	return m.mockSecretManagerClient.AccessSecretVersion(ctx, req, opts...)
+}
+
+func TestPing(t *testing.T) {
+	testCases := []struct {
+		name        string
+		client      *pingRecorder
+		expectedErr error
+	}{
+		{name: "reachable", client: &pingRecorder{mockSecretManagerClient: mockSecretManagerClient{isSuccess: true}}},
+		{
+			name:        "secret not found",
+			client:      &pingRecorder{mockSecretManagerClient: mockSecretManagerClient{errs: []error{status.Error(codes.NotFound, "missing")}}},
+			expectedErr: ErrSecretNotFound,
+		},
+		{
+			name:        "unavailable is not retried",
+			client:      &pingRecorder{mockSecretManagerClient: mockSecretManagerClient{errs: []error{status.Error(codes.Unavailable, "down"), nil}, isSuccess: true}},
+			expectedErr: ErrUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: tc.client,
+				config: &Config{ProjectID: "p", SecretName: "s", SecretVersion: LatestVersion, Retry: RetryConfig{MaxAttempts: 3}},
+			}
+
+			err := c.Ping(context.Background())
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, 1, tc.client.calls)
+			assert.Zero(t, tc.client.accessCalls)
+		})
+	}
+}
+
+func TestPingDeadline(t *testing.T) {
+	mock := &pingRecorder{mockSecretManagerClient: mockSecretManagerClient{isSuccess: true}}
+	c := &Client{client: mock, config: &Config{Timeout: time.Millisecond}}
+
+	// The caller deadline is used as is, even when longer than Timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	expected, _ := ctx.Deadline()
+	assert.NoError(t, c.Ping(ctx))
+	assert.Equal(t, expected, mock.deadline)
+
+	// Timeout applies without caller deadline
+	start := time.Now()
+	assert.NoError(t, c.Ping(context.Background()))
+	assert.WithinDuration(t, start.Add(time.Millisecond), mock.deadline, 100*time.Millisecond)
+}
@@ -0,0 +1,52 @@
+package GCPSecretManager
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CollectIndexed reassembles a list stored in environment variables named
+// PREFIX_0, PREFIX_1, and so on, for example after LoadSecretToEnv, and
+// returns their values ordered by index. See CollectIndexedFrom for the
+// rules applied.
+//
+// Parameters:
+// - prefix: The name of the list, without the trailing '_'.
+//
+// Returns:
+// - The values from index 0 up to the first missing index, or nil if
+// PREFIX_0 is not set.
+func CollectIndexed(prefix string) []string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			env[key] = value
+		}
+	}
+	return CollectIndexedFrom(env, prefix)
+}
+
+// CollectIndexedFrom reassembles a list stored in values under the keys
+// PREFIX_0, PREFIX_1, and so on. Indexes are decimal without leading zeros,
+// so PREFIX_01 is not part of the list. Collection stops at the first gap in
+// the sequence: with PREFIX_0, PREFIX_1 and PREFIX_3, only the first two
+// values are returned. A present key with an empty value is not a gap.
+//
+// Parameters:
+// - values: The key-value pairs to scan, such as parsed secret values.
+// - prefix: The name of the list, without the trailing '_'.
+//
+// Returns:
+// - The values from index 0 up to the first missing index, or nil if
+// PREFIX_0 is missing.
+func CollectIndexedFrom(values map[string]string, prefix string) []string {
+	var list []string
+	for i := 0; ; i++ {
+		value, ok := values[prefix+"_"+strconv.Itoa(i)]
+		if !ok {
+			return list
+		}
+		list = append(list, value)
+	}
+}
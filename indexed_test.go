@@ -0,0 +1,67 @@
+package GCPSecretManager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectIndexedFrom(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prefix   string
+		values   map[string]string
+		expected []string
+	}{
+		{
+			name:     "ordered by index",
+			prefix:   "SERVERS",
+			values:   map[string]string{"SERVERS_2": "c", "SERVERS_0": "a", "SERVERS_1": "b", "OTHER_0": "x"},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "indexes past ten",
+			prefix:   "S",
+			values:   map[string]string{"S_0": "0", "S_1": "1", "S_2": "2", "S_3": "3", "S_4": "4", "S_5": "5", "S_6": "6", "S_7": "7", "S_8": "8", "S_9": "9", "S_10": "10"},
+			expected: []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10"},
+		},
+		{
+			name:     "stops at first gap",
+			prefix:   "SERVERS",
+			values:   map[string]string{"SERVERS_0": "a", "SERVERS_1": "b", "SERVERS_3": "d"},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "empty value is kept",
+			prefix:   "SERVERS",
+			values:   map[string]string{"SERVERS_0": "", "SERVERS_1": "b"},
+			expected: []string{"", "b"},
+		},
+		{
+			name:     "leading zeros ignored",
+			prefix:   "SERVERS",
+			values:   map[string]string{"SERVERS_0": "a", "SERVERS_01": "b"},
+			expected: []string{"a"},
+		},
+		{
+			name:   "no first index",
+			prefix: "SERVERS",
+			values: map[string]string{"SERVERS_1": "b"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, CollectIndexedFrom(tc.values, tc.prefix))
+		})
+	}
+}
+
+func TestCollectIndexed(t *testing.T) {
+	t.Setenv("INDEXED_SERVERS_0", "a")
+	t.Setenv("INDEXED_SERVERS_1", "b=c")
+	t.Setenv("INDEXED_SERVERS_3", "d")
+
+	assert.Equal(t, []string{"a", "b=c"}, CollectIndexed("INDEXED_SERVERS"))
+	assert.Nil(t, CollectIndexed("INDEXED_MISSING"))
+}
@@ -0,0 +1,140 @@
+package GCPSecretManager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// defaultJSONSeparator joins nested JSON object keys when Config.JSONSeparator is empty.
+const defaultJSONSeparator = "_"
+
+// ErrInvalidJSON is returned when a secret payload cannot be decoded as a JSON object.
+var ErrInvalidJSON = errors.New("secret payload is not a valid JSON object")
+
+// LoadJSONSecretToEnv retrieves the secret from Secret Manager, decodes it as a
+// JSON object and sets each key as an environment variable. Scalar values are
+// converted to strings, null becomes an empty string, and arrays are
+// JSON-encoded. Nested objects are flattened by joining the keys with
+// Config.JSONSeparator, for example {"db":{"host":"x"}} sets db_host=x.
+// Keys are then mapped by Config.KeyTransform and Config.NormalizeKeysUpper
+// and validated like the keys of KEY=VALUE lines.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - An error wrapping ErrInvalidJSON if the payload is not a JSON object.
+// - An error wrapping ErrInvalidKey if a key is not a valid environment
+// variable name, in which case nothing is set.
+// - An error if the secret retrieval or environment variable setting fails.
+func (c *Client) LoadJSONSecretToEnv(ctx context.Context) error {
+	return c.LoadDecodedSecretToEnv(ctx, decodeJSON)
+}
+
+// LoadDecodedSecretToEnv retrieves the secret from Secret Manager, decodes it
+// with decode and sets each key as an environment variable, flattening nested
+// mappings like LoadJSONSecretToEnv does. It allows structured formats whose
+// dependencies live outside this package, such as the yamlsecret subpackage.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - decode: The function decoding the payload into a mapping.
+//
+// Returns:
+// - The error returned by decode, if any.
+// - An error wrapping ErrInvalidKey if a key is not a valid environment
+// variable name, in which case nothing is set.
+// - An error if the secret retrieval or environment variable setting fails.
+func (c *Client) LoadDecodedSecretToEnv(ctx context.Context, decode func(data []byte) (map[string]any, error)) error {
+	// Get the secret content
+	data, err := c.GetSecretBytes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve secret: %w", err)
+	}
+
+	decoded, err := decode(data)
+	if err != nil {
+		return err
+	}
+
+	values, err := flattenValues(decoded, c.config.JSONSeparator)
+	if err != nil {
+		return err
+	}
+
+	// Map and validate the keys like those of KEY=VALUE lines
+	_, err = c.config.setEnvValues(c.config.envKeys(values))
+	return err
+}
+
+// decodeJSON decodes data as a JSON object. Numbers are decoded as
+// json.Number to keep their original formatting.
+func decodeJSON(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var decoded map[string]any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	if decoded == nil {
+		return nil, fmt.Errorf("%w: payload is null", ErrInvalidJSON)
+	}
+	return decoded, nil
+}
+
+// flattenValues flattens a decoded mapping into a map of string values.
+//
+// Parameters:
+// - data: The decoded mapping.
+// - separator: The separator used to join nested keys, "_" when empty.
+//
+// Returns:
+// - A map containing the flattened key-value pairs.
+// - An error if a value cannot be encoded.
+func flattenValues(data map[string]any, separator string) (map[string]string, error) {
+	if separator == "" {
+		separator = defaultJSONSeparator
+	}
+
+	values := make(map[string]string)
+	if err := flattenInto("", separator, data, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// flattenInto writes every leaf of data into values, prefixing nested keys
+// with their parent key and the separator. Scalars are formatted as text,
+// null becomes an empty string and sequences are JSON-encoded.
+func flattenInto(prefix, separator string, data map[string]any, values map[string]string) error {
+	for k, v := range data {
+		key := k
+		if prefix != "" {
+			key = prefix + separator + k
+		}
+
+		switch val := v.(type) {
+		case map[string]any:
+			if err := flattenInto(key, separator, val, values); err != nil {
+				return err
+			}
+		case string:
+			values[key] = val
+		case nil:
+			values[key] = ""
+		case []any:
+			encoded, err := json.Marshal(val)
+			if err != nil {
+				return fmt.Errorf("failed to encode value of %s: %w", key, err)
+			}
+			values[key] = string(encoded)
+		default:
+			values[key] = fmt.Sprint(val)
+		}
+	}
+	return nil
+}
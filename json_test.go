@@ -0,0 +1,159 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeAndFlattenJSON(t *testing.T) {
+	testCases := []struct {
+		name           string
+		content        string
+		separator      string
+		expectedValues map[string]string
+		expectedErr    error
+	}{
+		{
+			name:    "success flatten with default separator",
+			content: `{"name":"app","port":8080,"ratio":1.5,"debug":true,"empty":null,"tags":["a","b"],"db":{"host":"localhost","opts":{"ssl":false}}}`,
+			expectedValues: map[string]string{
+				"name":        "app",
+				"port":        "8080",
+				"ratio":       "1.5",
+				"debug":       "true",
+				"empty":       "",
+				"tags":        `["a","b"]`,
+				"db_host":     "localhost",
+				"db_opts_ssl": "false",
+			},
+		},
+		{
+			name:      "success flatten with custom separator",
+			content:   `{"db":{"host":"localhost"}}`,
+			separator: ".",
+			expectedValues: map[string]string{
+				"db.host": "localhost",
+			},
+		},
+		{
+			name:        "fail with invalid json",
+			content:     "FOO=bar",
+			expectedErr: ErrInvalidJSON,
+		},
+		{
+			name:        "fail with json array",
+			content:     `["FOO"]`,
+			expectedErr: ErrInvalidJSON,
+		},
+		{
+			name:        "fail with json null",
+			content:     `null`,
+			expectedErr: ErrInvalidJSON,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := decodeJSON([]byte(tc.content))
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			values, err := flattenValues(decoded, tc.separator)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValues, values)
+		})
+	}
+}
+
+func TestLoadJSONSecretToEnv(t *testing.T) {
+	c := &Client{
+		client: &mockSecretManagerClient{
+			secretPayload: `{"JSON_TEST_KEY":"value","JSON_TEST":{"NESTED":1}}`,
+			isSuccess:     true,
+		},
+		config: &Config{},
+	}
+	t.Cleanup(func() {
+		os.Unsetenv("JSON_TEST_KEY")
+		os.Unsetenv("JSON_TEST_NESTED")
+	})
+
+	err := c.LoadJSONSecretToEnv(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "value", os.Getenv("JSON_TEST_KEY"))
+	assert.Equal(t, "1", os.Getenv("JSON_TEST_NESTED"))
+}
+
+func TestLoadJSONSecretToEnvKeys(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      Config
+		payload     string
+		expected    map[string]string
+		expectedErr error
+	}{
+		{
+			name:        "key with space",
+			payload:     `{"JSON_KEY_OK":"1","my key":"x"}`,
+			expectedErr: ErrInvalidKey,
+		},
+		{
+			name:        "dotted key",
+			payload:     `{"JSON_KEY_OK":"1","a.b":"x"}`,
+			expectedErr: ErrInvalidKey,
+		},
+		{
+			name:        "key starting with a digit",
+			payload:     `{"JSON_KEY_OK":"1","1X":"x"}`,
+			expectedErr: ErrInvalidKey,
+		},
+		{
+			name:     "filtered invalid key",
+			config:   Config{KeyFilter: DenyKeys("a.b")},
+			payload:  `{"JSON_KEY_OK":"1","a.b":"x"}`,
+			expected: map[string]string{"JSON_KEY_OK": "1"},
+		},
+		{
+			name:     "loose keys",
+			config:   Config{LooseKeys: true},
+			payload:  `{"JSON_KEY.LOOSE":"1"}`,
+			expected: map[string]string{"JSON_KEY.LOOSE": "1"},
+		},
+		{
+			name:     "transformed keys",
+			config:   Config{KeyTransform: KeyToUnderscore, NormalizeKeysUpper: true},
+			payload:  `{"json_key.db":{"password":"p"}}`,
+			expected: map[string]string{"JSON_KEY_DB_PASSWORD": "p"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("JSON_KEY_OK", "")
+			os.Unsetenv("JSON_KEY_OK")
+			c := &Client{
+				client: &mockSecretManagerClient{secretPayload: tc.payload, isSuccess: true},
+				config: &tc.config,
+			}
+
+			err := c.LoadJSONSecretToEnv(context.Background())
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				_, set := os.LookupEnv("JSON_KEY_OK")
+				assert.False(t, set)
+				return
+			}
+			assert.NoError(t, err)
+			for key, value := range tc.expected {
+				t.Cleanup(func() { os.Unsetenv(key) })
+				assert.Equal(t, value, os.Getenv(key))
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+)
+
+// ErrListNotSupported is returned by ListSecrets when the underlying client
+// cannot list secrets.
+var ErrListNotSupported = errors.New("secret manager client does not support listing secrets")
+
+// ErrEmptyFilter is returned by ListSecretsByFilter when the filter is empty.
+var ErrEmptyFilter = errors.New("secret list filter must not be empty")
+
+// secretListerClient is implemented by clients that can list secrets.
+type secretListerClient interface {
+	ListSecrets(ctx context.Context, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) *secretmanager.SecretIterator
+}
+
+var _ secretListerClient = (*secretmanager.Client)(nil)
+
+// secretIterator is implemented by *secretmanager.SecretIterator, which
+// fetches the following pages transparently.
+type secretIterator interface {
+	Next() (*secretmanagerpb.Secret, error)
+}
+
+var newSecretIterator = func(ctx context.Context, client SecretManagerClient, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) (secretIterator, error) {
+	lister, ok := client.(secretListerClient)
+	if !ok {
+		return nil, ErrListNotSupported
+	}
+	return lister.ListSecrets(ctx, req, opts...), nil
+}
+
+// secretVersionListerClient is implemented by clients that can list the
+// versions of a secret.
+type secretVersionListerClient interface {
+	ListSecretVersions(ctx context.Context, req *secretmanagerpb.ListSecretVersionsRequest, opts ...gax.CallOption) *secretmanager.SecretVersionIterator
+}
+
+var _ secretVersionListerClient = (*secretmanager.Client)(nil)
+
+// secretVersionIterator is implemented by *secretmanager.SecretVersionIterator,
+// which fetches the following pages transparently.
+type secretVersionIterator interface {
+	Next() (*secretmanagerpb.SecretVersion, error)
+}
+
+var newSecretVersionIterator = func(ctx context.Context, client SecretManagerClient, req *secretmanagerpb.ListSecretVersionsRequest, opts ...gax.CallOption) (secretVersionIterator, error) {
+	lister, ok := client.(secretVersionListerClient)
+	if !ok {
+		return nil, ErrListNotSupported
+	}
+	return lister.ListSecretVersions(ctx, req, opts...), nil
+}
+
+// ListSecrets lists the secrets of the configured project, or of the
+// configured location for regional secrets. Every page is fetched, so the
+// returned list is complete. Config.Timeout applies to each page request
+// rather than to the whole listing, unless ctx carries its own deadline.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - The short names of the secrets, without the "projects/PROJECT_ID/secrets/" prefix.
+// - An error if listing the secrets fails.
+func (c *Client) ListSecrets(ctx context.Context) ([]string, error) {
+	return c.listSecrets(ctx, "")
+}
+
+// ListSecretsByFilter lists the secrets of the configured project or location
+// that match filter, which is evaluated by Secret Manager so only matching
+// secrets are transferred. Every page is fetched, so the returned list is
+// complete.
+//
+// The filter uses the Secret Manager list filter syntax, described at
+// https://cloud.google.com/secret-manager/docs/filtering, for example
+// "labels.app=billing" or "labels.app=billing AND name:db".
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - filter: The filter expression, which must not be empty.
+//
+// Returns:
+// - The short names of the matching secrets.
+// - ErrEmptyFilter if filter is empty or blank.
+// - An error if listing the secrets fails, for example if filter is invalid.
+func (c *Client) ListSecretsByFilter(ctx context.Context, filter string) ([]string, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, ErrEmptyFilter
+	}
+	return c.listSecrets(ctx, filter)
+}
+
+// listSecrets lists the secrets of the configured project or location,
+// applying filter when it is not empty.
+func (c *Client) listSecrets(ctx context.Context, filter string) ([]string, error) {
+	// Create the request to list the secrets of the project or location
+	req := &secretmanagerpb.ListSecretsRequest{
+		Parent: c.config.parentPath(),
+		Filter: filter,
+	}
+
+	// Limit the duration of each page request, since the iterator fetches
+	// the following pages lazily
+	it, err := newSecretIterator(ctx, c.client, req, c.config.pageCallOptions()...)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for {
+		secret, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+		names = append(names, shortSecretName(secret.GetName()))
+	}
+
+	return names, nil
+}
+
+// pageCallOptions returns the call options applying the per-call timeout to
+// each page request of a listing. The iterator keeps the context it was
+// created with, so callContext cannot be used; as with any gax timeout, it
+// is ignored when the context already has a deadline.
+func (c *Config) pageCallOptions() []gax.CallOption {
+	if c.ContextDeadlineOnly {
+		return nil
+	}
+	return []gax.CallOption{gax.WithTimeout(c.timeout())}
+}
+
+// shortSecretName returns the last segment of a secret resource name.
+func shortSecretName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// GetAllVersions retrieves the payload of every enabled version of the
+// configured secret, for auditing purposes. Disabled and destroyed versions
+// cannot be accessed and are skipped. Every page of versions is listed, then
+// each version is accessed with its own API call, with at most
+// Config.MaxConcurrency calls at once, so this may be slow and consume a
+// significant part of the access quota for secrets with many versions.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - A map of payloads keyed by version number.
+// - An error if listing the versions or accessing any of them fails.
+func (c *Client) GetAllVersions(ctx context.Context) (map[string]string, error) {
+	versions, err := c.listEnabledVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]SecretRef, len(versions))
+	for i, version := range versions {
+		refs[i] = SecretRef{Name: c.config.SecretName, Version: version}
+	}
+	results := c.accessAll(ctx, refs, true)
+
+	payloads := make(map[string]string, len(versions))
+	for i, version := range versions {
+		if results[i].err != nil {
+			return nil, fmt.Errorf("version %s: %w", version, results[i].err)
+		}
+		payloads[version] = trimBOM(string(results[i].response.Payload.Data))
+	}
+
+	return payloads, nil
+}
+
+// listEnabledVersions returns the numbers of the enabled versions of the
+// configured secret, across every page. Config.Timeout applies to each page
+// request.
+func (c *Client) listEnabledVersions(ctx context.Context) ([]string, error) {
+	// Create the request to list the versions of the secret
+	req := &secretmanagerpb.ListSecretVersionsRequest{
+		Parent: c.config.secretPath(c.config.SecretName),
+	}
+
+	// Limit the duration of each page request, since the iterator fetches
+	// the following pages lazily
+	it, err := newSecretVersionIterator(ctx, c.client, req, c.config.pageCallOptions()...)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for {
+		version, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secret versions: %w", err)
+		}
+		if version.GetState() != secretmanagerpb.SecretVersion_ENABLED {
+			continue
+		}
+		versions = append(versions, shortSecretName(version.GetName()))
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,313 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockSecretIterator returns canned pages of secrets, then the configured
+// error or iterator.Done.
+type mockSecretIterator struct {
+	pages [][]*secretmanagerpb.Secret
+	err   error
+}
+
+func (m *mockSecretIterator) Next() (*secretmanagerpb.Secret, error) {
+	for len(m.pages) > 0 && len(m.pages[0]) == 0 {
+		m.pages = m.pages[1:]
+	}
+	if len(m.pages) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, iterator.Done
+	}
+
+	secret := m.pages[0][0]
+	m.pages[0] = m.pages[0][1:]
+	return secret, nil
+}
+
+func TestListSecrets(t *testing.T) {
+	originalSecretIterator := newSecretIterator
+	defer func() { newSecretIterator = originalSecretIterator }()
+
+	ctx := context.Background()
+
+	testCases := []struct {
+		name           string
+		location       string
+		iterator       *mockSecretIterator
+		expectedParent string
+		expectedNames  []string
+		expectedErr    error
+	}{
+		{
+			name: "success across pages",
+			iterator: &mockSecretIterator{pages: [][]*secretmanagerpb.Secret{
+				{{Name: "projects/test-id/secrets/first"}, {Name: "projects/test-id/secrets/second"}},
+				{{Name: "projects/test-id/secrets/third"}},
+			}},
+			expectedParent: "projects/test-id",
+			expectedNames:  []string{"first", "second", "third"},
+		},
+		{
+			name:     "success with regional secrets",
+			location: "europe-west4",
+			iterator: &mockSecretIterator{pages: [][]*secretmanagerpb.Secret{
+				{{Name: "projects/test-id/locations/europe-west4/secrets/regional"}},
+			}},
+			expectedParent: "projects/test-id/locations/europe-west4",
+			expectedNames:  []string{"regional"},
+		},
+		{
+			name:           "fail to list secrets",
+			iterator:       &mockSecretIterator{err: fmt.Errorf("list error")},
+			expectedParent: "projects/test-id",
+			expectedErr:    fmt.Errorf("failed to list secrets"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var parent string
+			newSecretIterator = func(ctx context.Context, client SecretManagerClient, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) (secretIterator, error) {
+				parent = req.Parent
+				return tc.iterator, nil
+			}
+
+			c := &Client{
+				client: &mockSecretManagerClient{},
+				config: &Config{ProjectID: "test-id", Location: tc.location},
+			}
+
+			names, err := c.ListSecrets(ctx)
+			assert.Equal(t, tc.expectedParent, parent)
+			if tc.expectedErr != nil {
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestListSecretsPageTimeout(t *testing.T) {
+	originalSecretIterator := newSecretIterator
+	defer func() { newSecretIterator = originalSecretIterator }()
+
+	testCases := []struct {
+		name         string
+		config       *Config
+		expectedOpts int
+	}{
+		{
+			name:         "timeout applied to each page",
+			config:       &Config{ProjectID: "test-id", Timeout: time.Second},
+			expectedOpts: 1,
+		},
+		{
+			name:   "context deadline only",
+			config: &Config{ProjectID: "test-id", ContextDeadlineOnly: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			newSecretIterator = func(ctx context.Context, client SecretManagerClient, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) (secretIterator, error) {
+				// The listing as a whole is not bound by the timeout
+				_, hasDeadline := ctx.Deadline()
+				assert.False(t, hasDeadline)
+				assert.Len(t, opts, tc.expectedOpts)
+				return &mockSecretIterator{}, nil
+			}
+
+			c := &Client{client: &mockSecretManagerClient{}, config: tc.config}
+			_, err := c.ListSecrets(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestListSecretsNotSupported(t *testing.T) {
+	c := &Client{client: &mockSecretManagerClient{}, config: &Config{ProjectID: "test-id"}}
+
+	_, err := c.ListSecrets(context.Background())
+	assert.ErrorIs(t, err, ErrListNotSupported)
+}
+
+// mockSecretVersionIterator returns canned pages of secret versions, then the
+// configured error or iterator.Done.
+type mockSecretVersionIterator struct {
+	pages [][]*secretmanagerpb.SecretVersion
+	err   error
+}
+
+func (m *mockSecretVersionIterator) Next() (*secretmanagerpb.SecretVersion, error) {
+	for len(m.pages) > 0 && len(m.pages[0]) == 0 {
+		m.pages = m.pages[1:]
+	}
+	if len(m.pages) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, iterator.Done
+	}
+
+	version := m.pages[0][0]
+	m.pages[0] = m.pages[0][1:]
+	return version, nil
+}
+
+// versionPayloadClient returns a payload per version resource name.
+type versionPayloadClient struct {
+	mockSecretManagerClient
+	payloads map[string]string
+}
+
+func (m *versionPayloadClient) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	payload, ok := m.payloads[req.Name]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return &secretmanagerpb.AccessSecretVersionResponse{
+		Payload: &secretmanagerpb.SecretPayload{Data: []byte(payload)},
+	}, nil
+}
+
+func TestGetAllVersions(t *testing.T) {
+	originalSecretVersionIterator := newSecretVersionIterator
+	defer func() { newSecretVersionIterator = originalSecretVersionIterator }()
+
+	const prefix = "projects/test-id/secrets/test-name/versions/"
+	payloads := map[string]string{
+		prefix + "1": "KEY=one",
+		prefix + "3": "KEY=three",
+	}
+
+	testCases := []struct {
+		name        string
+		iterator    *mockSecretVersionIterator
+		expected    map[string]string
+		expectedErr string
+	}{
+		{
+			name: "skips disabled and destroyed versions across pages",
+			iterator: &mockSecretVersionIterator{pages: [][]*secretmanagerpb.SecretVersion{
+				{
+					{Name: prefix + "4", State: secretmanagerpb.SecretVersion_DESTROYED},
+					{Name: prefix + "3", State: secretmanagerpb.SecretVersion_ENABLED},
+				},
+				{
+					{Name: prefix + "2", State: secretmanagerpb.SecretVersion_DISABLED},
+					{Name: prefix + "1", State: secretmanagerpb.SecretVersion_ENABLED},
+				},
+			}},
+			expected: map[string]string{"1": "KEY=one", "3": "KEY=three"},
+		},
+		{
+			name:        "fail to list versions",
+			iterator:    &mockSecretVersionIterator{err: fmt.Errorf("list error")},
+			expectedErr: "failed to list secret versions: list error",
+		},
+		{
+			name: "fail to access a version",
+			iterator: &mockSecretVersionIterator{pages: [][]*secretmanagerpb.SecretVersion{
+				{{Name: prefix + "5", State: secretmanagerpb.SecretVersion_ENABLED}},
+			}},
+			expectedErr: "version 5: failed to access secret",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var parent string
+			newSecretVersionIterator = func(ctx context.Context, client SecretManagerClient, req *secretmanagerpb.ListSecretVersionsRequest, opts ...gax.CallOption) (secretVersionIterator, error) {
+				parent = req.Parent
+				_, hasDeadline := ctx.Deadline()
+				assert.False(t, hasDeadline)
+				assert.Len(t, opts, 1)
+				return tc.iterator, nil
+			}
+
+			c := &Client{
+				client: &versionPayloadClient{payloads: payloads},
+				config: &Config{ProjectID: "test-id", SecretName: "test-name"},
+			}
+
+			versions, err := c.GetAllVersions(context.Background())
+			assert.Equal(t, "projects/test-id/secrets/test-name", parent)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, versions)
+		})
+	}
+}
+
+func TestGetAllVersionsNotSupported(t *testing.T) {
+	c := &Client{client: &mockSecretManagerClient{}, config: &Config{ProjectID: "test-id", SecretName: "test-name"}}
+
+	_, err := c.GetAllVersions(context.Background())
+	assert.ErrorIs(t, err, ErrListNotSupported)
+}
+
+func TestListSecretsByFilter(t *testing.T) {
+	originalSecretIterator := newSecretIterator
+	defer func() { newSecretIterator = originalSecretIterator }()
+
+	testCases := []struct {
+		name           string
+		filter         string
+		expectedFilter string
+		expectedNames  []string
+		expectedErr    error
+	}{
+		{
+			name:           "label filter passed through",
+			filter:         "labels.app=billing",
+			expectedFilter: "labels.app=billing",
+			expectedNames:  []string{"billing-db"},
+		},
+		{name: "empty filter", filter: "", expectedErr: ErrEmptyFilter},
+		{name: "blank filter", filter: "  ", expectedErr: ErrEmptyFilter},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var filter string
+			called := false
+			newSecretIterator = func(ctx context.Context, client SecretManagerClient, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) (secretIterator, error) {
+				called = true
+				filter = req.Filter
+				return &mockSecretIterator{pages: [][]*secretmanagerpb.Secret{
+					{{Name: "projects/test-id/secrets/billing-db"}},
+				}}, nil
+			}
+
+			c := &Client{client: &mockSecretManagerClient{}, config: &Config{ProjectID: "test-id"}}
+
+			names, err := c.ListSecretsByFilter(context.Background(), tc.filter)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.False(t, called)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFilter, filter)
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// LiveSecret holds the latest value of a secret, refreshed in the background
+// by polling Secret Manager like WatchSecret. It is meant for hot-reloadable
+// configuration: Get always returns the freshest known value without
+// locking. A LiveSecret is safe for concurrent use.
+type LiveSecret struct {
+	value  atomic.Value
+	errs   chan error
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewLiveSecret fetches the latest version of the secret configured on client
+// and starts polling it every interval in the background. Polling stops when
+// ctx is cancelled or Stop is called.
+//
+// Parameters:
+// - ctx: The context controlling the polling.
+// - client: The client used to access the secret.
+// - interval: The delay between two polls, which must be positive.
+//
+// Returns:
+// - A pointer to a LiveSecret holding the current value.
+// - An error if interval is not positive or the initial fetch fails, in which
+// case no polling is started.
+func NewLiveSecret(ctx context.Context, client *Client, interval time.Duration) (*LiveSecret, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("watch interval must be positive, got %s", interval)
+	}
+
+	// Fetch the initial value before starting the polling
+	initial, err := client.accessSecretVersion(ctx, client.config.SecretName, LatestVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve secret: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	ls := &LiveSecret{
+		errs:   make(chan error, 1),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	ls.value.Store(trimBOM(string(initial.Payload.Data)))
+
+	go func() {
+		defer close(ls.done)
+		defer close(ls.errs)
+		client.pollSecret(ctx, interval, initial, func(newValue string) {
+			ls.value.Store(newValue)
+		}, ls.reportError)
+	}()
+
+	return ls, nil
+}
+
+// Get returns the latest known value of the secret. After a failed poll, the
+// previous value is kept.
+func (ls *LiveSecret) Get() string {
+	return ls.value.Load().(string)
+}
+
+// Errors returns a channel receiving the errors of failed polls. It holds at
+// most one pending error: errors occurring while one is pending are dropped,
+// so the polling never blocks on a slow reader. The channel is closed once
+// polling stops.
+func (ls *LiveSecret) Errors() <-chan error {
+	return ls.errs
+}
+
+// Stop ends the polling and waits for it to return. Get keeps returning the
+// last known value. It is safe to call Stop more than once.
+func (ls *LiveSecret) Stop() {
+	ls.cancel()
+	<-ls.done
+}
+
+// reportError sends err on the error channel unless an error is pending.
+func (ls *LiveSecret) reportError(err error) {
+	select {
+	case ls.errs <- err:
+	default:
+	}
+}
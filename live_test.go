@@ -0,0 +1,72 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveSecret(t *testing.T) {
+	mock := &sequenceMockClient{payloads: []string{"v1", "v1", "", "v2"}}
+	c := &Client{client: mock, config: &Config{ProjectID: "p", SecretName: "s"}}
+
+	ls, err := NewLiveSecret(context.Background(), c, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", ls.Get())
+
+	select {
+	case err := <-ls.Errors():
+		assert.ErrorContains(t, err, "failed to access secret")
+	case <-time.After(5 * time.Second):
+		t.Fatal("poll error not reported")
+	}
+
+	assert.Eventually(t, func() bool { return ls.Get() == "v2" }, 5*time.Second, time.Millisecond)
+
+	ls.Stop()
+	ls.Stop()
+	assert.Equal(t, "v2", ls.Get())
+	for range ls.Errors() {
+		// Drain pending errors until the channel is closed
+	}
+}
+
+func TestLiveSecretStripsBOM(t *testing.T) {
+	mock := &sequenceMockClient{payloads: []string{"\ufeffv1", "\ufeffv2"}}
+	c := &Client{client: mock, config: &Config{ProjectID: "p", SecretName: "s"}}
+
+	ls, err := NewLiveSecret(context.Background(), c, time.Millisecond)
+	assert.NoError(t, err)
+	defer ls.Stop()
+	assert.Equal(t, "v1", ls.Get())
+	assert.Eventually(t, func() bool { return ls.Get() == "v2" }, 5*time.Second, time.Millisecond)
+}
+
+func TestLiveSecretStopsWithContext(t *testing.T) {
+	mock := &sequenceMockClient{payloads: []string{"v1"}}
+	c := &Client{client: mock, config: &Config{ProjectID: "p", SecretName: "s"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ls, err := NewLiveSecret(ctx, c, time.Millisecond)
+	assert.NoError(t, err)
+
+	cancel()
+	select {
+	case <-ls.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("polling did not stop")
+	}
+	ls.Stop()
+}
+
+func TestNewLiveSecretErrors(t *testing.T) {
+	c := &Client{client: &sequenceMockClient{payloads: []string{""}}, config: &Config{ProjectID: "p", SecretName: "s"}}
+
+	_, err := NewLiveSecret(context.Background(), c, time.Millisecond)
+	assert.ErrorContains(t, err, "failed to retrieve secret")
+
+	_, err = NewLiveSecret(context.Background(), c, 0)
+	assert.ErrorContains(t, err, "watch interval must be positive")
+}
@@ -0,0 +1,74 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// LocalFileEnv is the environment variable read by NewSecretWithConfig when
+// Config.LocalFile is empty. Setting it switches the client to offline mode.
+const LocalFileEnv = "SECRET_LOCAL_FILE"
+
+// localFileClient serves secret content from a local file instead of the
+// Secret Manager API, allowing development without GCP credentials.
+type localFileClient struct {
+	path string
+}
+
+// AccessSecretVersion returns the content of the local file, whatever the
+// requested secret name and version.
+func (l *localFileClient) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local secret file: %w", err)
+	}
+
+	return &secretmanagerpb.AccessSecretVersionResponse{
+		Name: req.Name,
+		Payload: &secretmanagerpb.SecretPayload{
+			Data: data,
+		},
+	}, nil
+}
+
+// GetSecretVersion reports the local file as an enabled version created at
+// its last modification time.
+func (l *localFileClient) GetSecretVersion(ctx context.Context, req *secretmanagerpb.GetSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	info, err := os.Stat(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local secret file: %w", err)
+	}
+
+	return &secretmanagerpb.SecretVersion{
+		Name:       req.Name,
+		State:      secretmanagerpb.SecretVersion_ENABLED,
+		CreateTime: timestamppb.New(info.ModTime()),
+	}, nil
+}
+
+// GetSecret reports the secret as existing when the local file exists, and
+// returns a NotFound status otherwise.
+func (l *localFileClient) GetSecret(ctx context.Context, req *secretmanagerpb.GetSecretRequest, opts ...gax.CallOption) (*secretmanagerpb.Secret, error) {
+	if _, err := os.Stat(l.path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, fmt.Errorf("failed to read local secret file: %w", err)
+	}
+
+	return &secretmanagerpb.Secret{Name: req.Name}, nil
+}
+
+// Close is a no-op for local files.
+func (l *localFileClient) Close() error {
+	return nil
+}
@@ -0,0 +1,64 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+)
+
+func TestLocalFile(t *testing.T) {
+	originDefaultClientFactory := defaultClientFactory
+	defer func() {
+		defaultClientFactory = originDefaultClientFactory
+	}()
+	defaultClientFactory = func(ctx context.Context, opts ...option.ClientOption) (SecretManagerClient, error) {
+		t.Fatal("real client must not be created in local file mode")
+		return nil, nil
+	}
+
+	path := filepath.Join(t.TempDir(), ".env")
+	assert.NoError(t, os.WriteFile(path, []byte("# local\nLOCAL_FILE_KEY=local\n"), 0o600))
+
+	ctx := context.Background()
+
+	testCases := []struct {
+		name   string
+		config Config
+		envVar string
+	}{
+		{name: "from config field", config: Config{LocalFile: path}},
+		{name: "from environment variable", envVar: path},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(LocalFileEnv, tc.envVar)
+			t.Setenv("LOCAL_FILE_KEY", "")
+
+			client, err := NewSecret(ctx, tc.config)
+			assert.NoError(t, err)
+
+			content, err := client.GetSecret(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, "# local\nLOCAL_FILE_KEY=local\n", content)
+
+			assert.NoError(t, client.LoadSecretToEnv(ctx))
+			assert.Equal(t, "local", os.Getenv("LOCAL_FILE_KEY"))
+			assert.NoError(t, client.Close())
+		})
+	}
+}
+
+func TestLocalFileMissing(t *testing.T) {
+	c := &Client{
+		client: &localFileClient{path: filepath.Join(t.TempDir(), "missing")},
+		config: &Config{},
+	}
+
+	_, err := c.GetSecret(context.Background())
+	assert.ErrorContains(t, err, "failed to read local secret file")
+}
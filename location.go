@@ -0,0 +1,107 @@
+package GCPSecretManager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"google.golang.org/api/option"
+)
+
+// LocationEnv is the environment variable read by NewSecretWithConfig when
+// Config.Location is empty.
+const LocationEnv = "SECRET_LOCATION"
+
+// ErrInvalidLocation is returned when Config.Location does not look like a
+// Google Cloud region.
+var ErrInvalidLocation = errors.New("invalid secret location")
+
+// locationPattern matches region names such as "europe-west4" or "us-central1".
+var locationPattern = regexp.MustCompile(`^[a-z]+(-[a-z]+)+[0-9]+$`)
+
+// validateLocation checks that location is a plausible region name.
+//
+// Parameters:
+// - location: The region of regional secrets.
+//
+// Returns:
+// - An error wrapping ErrInvalidLocation if the location is not a region name.
+func validateLocation(location string) error {
+	if !locationPattern.MatchString(location) {
+		return fmt.Errorf("%w: %q is not a region such as \"europe-west4\"", ErrInvalidLocation, location)
+	}
+	return nil
+}
+
+// globalEndpoint is the Secret Manager endpoint serving global secrets.
+const globalEndpoint = "secretmanager.googleapis.com:443"
+
+// EndpointForLocation returns the Secret Manager endpoint to use for secrets
+// of the given location, as selected by NewSecret from Config.Location.
+// Regional secrets are only served by the regional endpoint of their
+// location, for example "secretmanager.europe-west4.rep.googleapis.com:443".
+// Global secrets, whether their replication policy is automatic or
+// user-managed, are served by the global endpoint, returned for an empty
+// location, which routes reads to a nearby replica.
+//
+// Parameters:
+// - location: The region of regional secrets, or an empty string for global secrets.
+//
+// Returns:
+// - The host and port of the endpoint, suitable for option.WithEndpoint.
+func EndpointForLocation(location string) string {
+	if location == "" {
+		return globalEndpoint
+	}
+	return fmt.Sprintf("secretmanager.%s.rep.googleapis.com:443", location)
+}
+
+// resolveLocation fills Location from the SECRET_LOCATION environment
+// variable when it is not set and validates it.
+//
+// Returns:
+// - The client options targeting the regional endpoint, or nil for global secrets.
+// - An error wrapping ErrInvalidLocation if the location is not a region name.
+func (c *Config) resolveLocation() ([]option.ClientOption, error) {
+	if c.Location == "" {
+		c.Location = os.Getenv(LocationEnv)
+	}
+	if c.Location == "" {
+		return nil, nil
+	}
+
+	if err := validateLocation(c.Location); err != nil {
+		return nil, err
+	}
+	return []option.ClientOption{option.WithEndpoint(EndpointForLocation(c.Location))}, nil
+}
+
+// SecretResourceName returns the fully-qualified resource name of the
+// configured secret version, for example
+// "projects/PROJECT_ID/secrets/SECRET_NAME/versions/latest", or
+// "projects/PROJECT_ID/locations/LOCATION/secrets/SECRET_NAME/versions/latest"
+// for regional secrets. It is the name accessed by GetSecret.
+func (c *Client) SecretResourceName() string {
+	return c.config.versionPath(c.config.SecretName, c.config.SecretVersion)
+}
+
+// parentPath returns the resource name of the project, or of the location
+// within the project when Location is set.
+func (c *Config) parentPath() string {
+	if c.Location != "" {
+		return fmt.Sprintf("projects/%s/locations/%s", c.ProjectID, c.Location)
+	}
+	return fmt.Sprintf("projects/%s", c.ProjectID)
+}
+
+// secretPath returns the resource name of the named secret, using the
+// regional format when Location is set.
+func (c *Config) secretPath(secretName string) string {
+	return fmt.Sprintf("%s/secrets/%s", c.parentPath(), secretName)
+}
+
+// versionPath returns the resource name of a version of the named secret.
+func (c *Config) versionPath(secretName, version string) string {
+	return fmt.Sprintf("%s/versions/%s", c.secretPath(secretName), version)
+}
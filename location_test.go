@@ -0,0 +1,94 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+)
+
+func TestLocation(t *testing.T) {
+	originDefaultClientFactory := defaultClientFactory
+	defer func() {
+		defaultClientFactory = originDefaultClientFactory
+	}()
+
+	var received []option.ClientOption
+	recorder := &nameRecorder{mockSecretManagerClient: mockSecretManagerClient{isSuccess: true}}
+	defaultClientFactory = func(ctx context.Context, opts ...option.ClientOption) (SecretManagerClient, error) {
+		received = opts
+		return recorder, nil
+	}
+
+	ctx := context.Background()
+
+	testCases := []struct {
+		name         string
+		location     string
+		envVar       string
+		expectedPath string
+		expectedOpts []option.ClientOption
+		expectedErr  error
+	}{
+		{
+			name:         "global secret",
+			expectedPath: "projects/test-id/secrets/test-name/versions/latest",
+		},
+		{
+			name:         "regional secret from config",
+			location:     "europe-west4",
+			expectedPath: "projects/test-id/locations/europe-west4/secrets/test-name/versions/latest",
+			expectedOpts: []option.ClientOption{option.WithEndpoint("secretmanager.europe-west4.rep.googleapis.com:443")},
+		},
+		{
+			name:         "regional secret from environment variable",
+			envVar:       "us-central1",
+			expectedPath: "projects/test-id/locations/us-central1/secrets/test-name/versions/latest",
+			expectedOpts: []option.ClientOption{option.WithEndpoint("secretmanager.us-central1.rep.googleapis.com:443")},
+		},
+		{
+			name:        "fail with invalid location",
+			location:    "Europe/West",
+			expectedErr: ErrInvalidLocation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(LocationEnv, tc.envVar)
+			recorder.names = nil
+			received = nil
+
+			client, err := NewSecret(ctx, Config{
+				ProjectID:  "test-id",
+				SecretName: "test-name",
+				Location:   tc.location,
+			})
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOpts, received)
+
+			_, err = client.GetSecret(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tc.expectedPath}, recorder.names)
+		})
+	}
+}
+
+func TestSecretResourceName(t *testing.T) {
+	c := &Client{config: &Config{ProjectID: "p", SecretName: "s", SecretVersion: "3"}}
+	assert.Equal(t, "projects/p/secrets/s/versions/3", c.SecretResourceName())
+
+	c = &Client{config: &Config{ProjectID: "p", Location: "europe-west4", SecretName: "s", SecretVersion: "latest"}}
+	assert.Equal(t, "projects/p/locations/europe-west4/secrets/s/versions/latest", c.SecretResourceName())
+}
+
+func TestEndpointForLocation(t *testing.T) {
+	assert.Equal(t, "secretmanager.googleapis.com:443", EndpointForLocation(""))
+	assert.Equal(t, "secretmanager.europe-west4.rep.googleapis.com:443", EndpointForLocation("europe-west4"))
+	assert.Equal(t, "secretmanager.us-central1.rep.googleapis.com:443", EndpointForLocation("us-central1"))
+}
@@ -0,0 +1,110 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ManifestEnv is the environment variable read by LoadManifestFromEnv when no
+// other variable is given.
+const ManifestEnv = "SECRET_MANIFEST"
+
+// ErrInvalidManifest is returned when a secret manifest is not a JSON array
+// of {"name", "version"} objects.
+var ErrInvalidManifest = errors.New("invalid secret manifest")
+
+// manifestEntry is a secret listed in a manifest.
+type manifestEntry struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ParseSecretManifest parses a JSON manifest listing secrets to load, such as
+//
+//	[{"name":"a","version":"latest"},{"name":"b","version":"3"}]
+//
+// Each entry needs a non-empty name; an omitted or empty version defaults to
+// Config.SecretVersion when loaded. Unknown fields are rejected so typos are
+// not silently ignored.
+//
+// Parameters:
+// - manifest: The JSON manifest.
+//
+// Returns:
+// - The listed secrets, in order.
+// - An error wrapping ErrInvalidManifest if the manifest is malformed, empty,
+// or has an entry without name.
+func ParseSecretManifest(manifest string) ([]SecretRef, error) {
+	decoder := json.NewDecoder(strings.NewReader(manifest))
+	decoder.DisallowUnknownFields()
+
+	var entries []manifestEntry
+	if err := decoder.Decode(&entries); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("%w: unexpected data after the array", ErrInvalidManifest)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: no secret listed", ErrInvalidManifest)
+	}
+
+	refs := make([]SecretRef, len(entries))
+	for i, entry := range entries {
+		if entry.Name == "" {
+			return nil, fmt.Errorf("%w: entry %d has no name", ErrInvalidManifest, i)
+		}
+		refs[i] = SecretRef{Name: entry.Name, Version: entry.Version}
+	}
+	return refs, nil
+}
+
+// LoadManifestToEnv loads every secret listed in a JSON manifest, as parsed by
+// ParseSecretManifest, and merges them in order into the environment, with
+// the last-wins semantics of LoadMergedSecretsToEnv.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - manifest: The JSON manifest.
+//
+// Returns:
+// - An error wrapping ErrInvalidManifest if the manifest is malformed.
+// - An error if any secret retrieval, parsing, or environment variable setting fails.
+func (c *Client) LoadManifestToEnv(ctx context.Context, manifest string) error {
+	refs, err := ParseSecretManifest(manifest)
+	if err != nil {
+		return err
+	}
+	return c.LoadMergedSecretsToEnv(ctx, refs)
+}
+
+// LoadManifestFromEnv works like LoadManifestToEnv with the manifest read
+// from the environment variable envVar, or ManifestEnv when envVar is empty.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - envVar: The environment variable holding the manifest.
+//
+// Returns:
+// - An error wrapping ErrInvalidManifest if the variable is unset or the
+// manifest is malformed.
+// - An error if any secret retrieval, parsing, or environment variable setting fails.
+func (c *Client) LoadManifestFromEnv(ctx context.Context, envVar string) error {
+	if envVar == "" {
+		envVar = ManifestEnv
+	}
+
+	manifest, ok := os.LookupEnv(envVar)
+	if !ok {
+		return fmt.Errorf("%w: environment variable %s is not set", ErrInvalidManifest, envVar)
+	}
+	if err := c.LoadManifestToEnv(ctx, manifest); err != nil {
+		return fmt.Errorf("manifest from %s: %w", envVar, err)
+	}
+	return nil
+}
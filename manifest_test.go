@@ -0,0 +1,114 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSecretManifest(t *testing.T) {
+	testCases := []struct {
+		name        string
+		manifest    string
+		expected    []SecretRef
+		errContains string
+	}{
+		{
+			name:     "names and versions",
+			manifest: `[{"name":"a","version":"latest"},{"name":"b","version":"3"}]`,
+			expected: []SecretRef{{Name: "a", Version: "latest"}, {Name: "b", Version: "3"}},
+		},
+		{
+			name:     "version omitted",
+			manifest: ` [ {"name":"a"} ] `,
+			expected: []SecretRef{{Name: "a"}},
+		},
+		{name: "not json", manifest: `a,b`, errContains: "invalid secret manifest: invalid character"},
+		{name: "object instead of array", manifest: `{"name":"a"}`, errContains: "cannot unmarshal object"},
+		{name: "wrong field type", manifest: `[{"name":1}]`, errContains: "cannot unmarshal number"},
+		{name: "unknown field", manifest: `[{"name":"a","versoin":"3"}]`, errContains: `unknown field "versoin"`},
+		{name: "missing name", manifest: `[{"name":"a"},{"version":"3"}]`, errContains: "entry 1 has no name"},
+		{name: "empty array", manifest: `[]`, errContains: "no secret listed"},
+		{name: "trailing data", manifest: `[{"name":"a"}] [{"name":"b"}]`, errContains: "unexpected data after the array"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			refs, err := ParseSecretManifest(tc.manifest)
+			if tc.errContains != "" {
+				assert.ErrorIs(t, err, ErrInvalidManifest)
+				assert.ErrorContains(t, err, tc.errContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, refs)
+		})
+	}
+}
+
+func TestLoadManifestFromEnv(t *testing.T) {
+	payloads := map[string]string{
+		"base":     "MANIFEST_HOST=base-host\nMANIFEST_PORT=5432\n",
+		"override": "MANIFEST_HOST=override-host\n",
+	}
+
+	testCases := []struct {
+		name        string
+		envVar      string
+		env         map[string]string
+		expectedEnv map[string]string
+		errContains string
+	}{
+		{
+			name:        "default variable merges in order",
+			env:         map[string]string{ManifestEnv: `[{"name":"base"},{"name":"override","version":"2"}]`},
+			expectedEnv: map[string]string{"MANIFEST_HOST": "override-host", "MANIFEST_PORT": "5432"},
+		},
+		{
+			name:        "custom variable",
+			envVar:      "DEPLOY_SECRETS",
+			env:         map[string]string{"DEPLOY_SECRETS": `[{"name":"override"},{"name":"base"}]`},
+			expectedEnv: map[string]string{"MANIFEST_HOST": "base-host", "MANIFEST_PORT": "5432"},
+		},
+		{
+			name:        "unset variable",
+			errContains: "environment variable SECRET_MANIFEST is not set",
+		},
+		{
+			name:        "malformed manifest",
+			env:         map[string]string{ManifestEnv: `["base"]`},
+			errContains: "manifest from SECRET_MANIFEST: invalid secret manifest",
+		},
+		{
+			name:        "missing secret sets nothing",
+			env:         map[string]string{ManifestEnv: `[{"name":"base"},{"name":"absent"}]`},
+			expectedEnv: map[string]string{"MANIFEST_HOST": "", "MANIFEST_PORT": ""},
+			errContains: "secret absent version latest: failed to retrieve secret",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("MANIFEST_HOST", "")
+			t.Setenv("MANIFEST_PORT", "")
+			t.Setenv(ManifestEnv, "")
+			os.Unsetenv(ManifestEnv)
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			c := NewClient(&namedMockClient{payloads: payloads}, &Config{ProjectID: "p"})
+			err := c.LoadManifestFromEnv(context.Background(), tc.envVar)
+			if tc.errContains != "" {
+				assert.ErrorContains(t, err, tc.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			for key, value := range tc.expectedEnv {
+				assert.Equal(t, value, os.Getenv(key))
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+)
+
+// ErrMetadataNotSupported is returned by GetSecretMetadata when the
+// underlying client cannot read secret metadata.
+var ErrMetadataNotSupported = errors.New("secret manager client does not support reading secret metadata")
+
+// Replication policies reported in SecretMetadata.Replication.
+const (
+	ReplicationAutomatic   = "automatic"
+	ReplicationUserManaged = "user-managed"
+)
+
+// SecretMetadata holds the metadata of a secret, without any of its payloads.
+type SecretMetadata struct {
+	// Name is the resource name of the secret, for example
+	// "projects/PROJECT_ID/secrets/SECRET_NAME".
+	Name string
+	// Etag identifies the current state of the secret. Passing it back with an
+	// update makes the update fail if the secret was modified in between.
+	Etag string
+	// Labels are the labels attached to the secret.
+	Labels map[string]string
+	// Replication is ReplicationAutomatic or ReplicationUserManaged, or empty
+	// if Secret Manager did not report a policy.
+	Replication string
+	// ReplicaLocations lists the locations of the replicas of a user-managed
+	// secret. It is empty for automatic replication.
+	ReplicaLocations []string
+}
+
+// GetSecretMetadata reads the metadata of the configured secret, including
+// its etag for read-modify-write updates. No version is accessed.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - The metadata of the secret.
+// - ErrMetadataNotSupported if the client cannot read secret metadata.
+// - An error if the API call fails, wrapping the sentinel errors described
+// on GetSecret.
+func (c *Client) GetSecretMetadata(ctx context.Context) (*SecretMetadata, error) {
+	getter, ok := c.client.(secretGetterClient)
+	if !ok {
+		return nil, ErrMetadataNotSupported
+	}
+
+	name := c.config.secretPath(c.config.SecretName)
+	var secret *secretmanagerpb.Secret
+	err := withRetry(ctx, c.config.Retry, func() error {
+		// Add a timeout to the context to limit the duration of each API call
+		callCtx, cancel := c.config.callContext(ctx)
+		defer cancel()
+
+		var err error
+		secret, err = getter.GetSecret(callCtx, &secretmanagerpb.GetSecretRequest{Name: name})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret metadata %s: %w", c.config.errorName(name), classifyError(err))
+	}
+
+	metadata := &SecretMetadata{
+		Name:   secret.GetName(),
+		Etag:   secret.GetEtag(),
+		Labels: maps.Clone(secret.GetLabels()),
+	}
+	replication := secret.GetReplication()
+	switch {
+	case replication.GetAutomatic() != nil:
+		metadata.Replication = ReplicationAutomatic
+	case replication.GetUserManaged() != nil:
+		metadata.Replication = ReplicationUserManaged
+		for _, replica := range replication.GetUserManaged().GetReplicas() {
+			metadata.ReplicaLocations = append(metadata.ReplicaLocations, replica.GetLocation())
+		}
+	}
+
+	return metadata, nil
+}
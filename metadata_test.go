@@ -0,0 +1,84 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"testing"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetSecretMetadata(t *testing.T) {
+	testCases := []struct {
+		name        string
+		client      SecretManagerClient
+		expected    *SecretMetadata
+		expectedErr error
+	}{
+		{
+			name: "automatic replication",
+			client: &secretGetterMockClient{secret: &secretmanagerpb.Secret{
+				Name:   "projects/p/secrets/s",
+				Etag:   `"16f2a1b3c4d5e6"`,
+				Labels: map[string]string{"team": "core"},
+				Replication: &secretmanagerpb.Replication{
+					Replication: &secretmanagerpb.Replication_Automatic_{Automatic: &secretmanagerpb.Replication_Automatic{}},
+				},
+			}},
+			expected: &SecretMetadata{
+				Name:        "projects/p/secrets/s",
+				Etag:        `"16f2a1b3c4d5e6"`,
+				Labels:      map[string]string{"team": "core"},
+				Replication: ReplicationAutomatic,
+			},
+		},
+		{
+			name: "user-managed replication",
+			client: &secretGetterMockClient{secret: &secretmanagerpb.Secret{
+				Name: "projects/p/secrets/s",
+				Etag: `"abc"`,
+				Replication: &secretmanagerpb.Replication{
+					Replication: &secretmanagerpb.Replication_UserManaged_{UserManaged: &secretmanagerpb.Replication_UserManaged{
+						Replicas: []*secretmanagerpb.Replication_UserManaged_Replica{
+							{Location: "europe-west1"},
+							{Location: "us-east1"},
+						},
+					}},
+				},
+			}},
+			expected: &SecretMetadata{
+				Name:             "projects/p/secrets/s",
+				Etag:             `"abc"`,
+				Replication:      ReplicationUserManaged,
+				ReplicaLocations: []string{"europe-west1", "us-east1"},
+			},
+		},
+		{
+			name:        "not found",
+			client:      &secretGetterMockClient{getErr: status.Error(codes.NotFound, "secret not found")},
+			expectedErr: ErrSecretNotFound,
+		},
+		{
+			name:        "not supported",
+			client:      &mockSecretManagerClient{isSuccess: true},
+			expectedErr: ErrMetadataNotSupported,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{client: tc.client, config: &Config{ProjectID: "p", SecretName: "s"}}
+
+			metadata, err := c.GetSecretMetadata(context.Background())
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, metadata)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, metadata)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package GCPSecretManager
+
+import "time"
+
+// Observer receives a notification after each attempt to access a secret
+// version, including each retry. It can feed metrics such as access counts,
+// latency histograms and error rates per secret. Implementations must be
+// safe for concurrent use.
+type Observer interface {
+	// ObserveAccess is called with the short secret name, the duration of
+	// the API call and its error, nil on success.
+	ObserveAccess(secretName string, dur time.Duration, err error)
+}
+
+// ObserverFunc adapts a function to the Observer interface.
+type ObserverFunc func(secretName string, dur time.Duration, err error)
+
+// ObserveAccess calls f.
+func (f ObserverFunc) ObserveAccess(secretName string, dur time.Duration, err error) {
+	f(secretName, dur, err)
+}
+
+// observeAccess reports an access attempt to the configured Observer, if any.
+func (c *Config) observeAccess(secretName string, start time.Time, err error) {
+	if c.Observer != nil {
+		c.Observer.ObserveAccess(secretName, time.Since(start), err)
+	}
+}
+
+// AttemptObserver can be implemented by an Observer to also receive, once per
+// secret access, the number of attempts it consumed, retries included. A
+// rising attempt count is an early sign of an outage.
+type AttemptObserver interface {
+	// ObserveAttempts is called with the short secret name, the number of
+	// attempts made and the final error, nil on success.
+	ObserveAttempts(secretName string, attempts int, err error)
+}
+
+// observeAttempts reports the attempt count of an access to the configured
+// Observer, if it implements AttemptObserver.
+func (c *Config) observeAttempts(secretName string, attempts int, err error) {
+	if observer, ok := c.Observer.(AttemptObserver); ok {
+		observer.ObserveAttempts(secretName, attempts, err)
+	}
+}
@@ -0,0 +1,112 @@
+package GCPSecretManager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type accessRecord struct {
+	secretName string
+	err        error
+}
+
+func TestObserver(t *testing.T) {
+	var mu sync.Mutex
+	var records []accessRecord
+	observer := ObserverFunc(func(secretName string, dur time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.GreaterOrEqual(t, dur, time.Duration(0))
+		records = append(records, accessRecord{secretName: secretName, err: err})
+	})
+
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	c := &Client{
+		client: &mockSecretManagerClient{
+			secretPayload: "FOO=bar",
+			isSuccess:     true,
+			errs:          []error{unavailable},
+		},
+		config: &Config{
+			SecretName: "observed",
+			Observer:   observer,
+			Retry:      RetryConfig{MaxAttempts: 2, BaseDelay: time.Millisecond},
+		},
+	}
+
+	_, err := c.GetSecret(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []accessRecord{
+		{secretName: "observed", err: unavailable},
+		{secretName: "observed", err: nil},
+	}, records)
+}
+
+// attemptRecorder records both the individual attempts and the attempt counts.
+type attemptRecorder struct {
+	ObserverFunc
+	attempts []int
+	errs     []error
+}
+
+func (r *attemptRecorder) ObserveAttempts(secretName string, attempts int, err error) {
+	r.attempts = append(r.attempts, attempts)
+	r.errs = append(r.errs, err)
+}
+
+func TestAttemptLogging(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	testCases := []struct {
+		name             string
+		errs             []error
+		maxAttempts      int
+		expectedAttempts int
+		expectedFailures int
+		expectedErr      error
+	}{
+		{name: "first attempt succeeds", expectedAttempts: 1},
+		{name: "retried then succeeds", errs: []error{unavailable, unavailable}, maxAttempts: 3, expectedAttempts: 3, expectedFailures: 2},
+		{name: "retries exhausted", errs: []error{unavailable, unavailable}, maxAttempts: 2, expectedAttempts: 2, expectedFailures: 2, expectedErr: unavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := zerolog.New(&buf).Level(zerolog.DebugLevel)
+			observer := &attemptRecorder{ObserverFunc: func(string, time.Duration, error) {}}
+			c := &Client{
+				client: &mockSecretManagerClient{secretPayload: "FOO=bar", isSuccess: true, errs: tc.errs},
+				config: &Config{
+					SecretName: "observed",
+					Observer:   observer,
+					Logger:     &logger,
+					Retry:      RetryConfig{MaxAttempts: tc.maxAttempts, BaseDelay: time.Millisecond},
+				},
+			}
+
+			_, err := c.GetSecret(context.Background())
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, []int{tc.expectedAttempts}, observer.attempts)
+			assert.Equal(t, []error{tc.expectedErr}, observer.errs)
+			assert.Equal(t, tc.expectedFailures, strings.Count(buf.String(), "Secret access attempt failed"))
+			assert.Contains(t, buf.String(), fmt.Sprintf(`"attempts":%d,"message":"Secret access finished"`, tc.expectedAttempts))
+			assert.NotContains(t, buf.String(), "FOO=bar")
+		})
+	}
+}
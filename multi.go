@@ -0,0 +1,195 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// LoadAllSecretsToEnv retrieves every configured secret, Config.SecretName
+// followed by Config.SecretNames, and sets their KEY=VALUE lines as
+// environment variables. All secrets are read with the configured
+// SecretVersion through the same underlying Secret Manager connection, with
+// at most Config.MaxConcurrency calls at once. Environment variables are set
+// in the order of the secrets.
+//
+// By default loading stops at the first failing secret. When
+// Config.ContinueOnError is set, the remaining secrets are still loaded and
+// the failures are returned together.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - An error if any secret retrieval, parsing, or environment variable setting fails.
+func (c *Client) LoadAllSecretsToEnv(ctx context.Context) error {
+	names := c.config.allSecretNames()
+	refs := make([]SecretRef, len(names))
+	for i, name := range names {
+		refs[i] = SecretRef{Name: name, Version: c.config.SecretVersion}
+	}
+	results := c.accessAll(ctx, refs, !c.config.ContinueOnError)
+
+	var errs []error
+	for i, name := range names {
+		if results[i] == nil {
+			break
+		}
+		if err := c.loadSecretResultToEnv(ctx, name, results[i]); err != nil {
+			err = fmt.Errorf("secret %s: %w", name, err)
+			if !c.config.ContinueOnError {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// loadSecretResultToEnv sets the lines of the accessed secret name as
+// environment variables.
+func (c *Client) loadSecretResultToEnv(ctx context.Context, name string, result *accessResult) error {
+	if result.err != nil {
+		return fmt.Errorf("failed to retrieve secret: %w", result.err)
+	}
+
+	values, parseErr := c.config.parseSecret(string(result.response.Payload.Data), nil)
+	if parseErr != nil {
+		parseErr = fmt.Errorf("failed to parse secret: %w", parseErr)
+		if values == nil {
+			return parseErr
+		}
+	}
+	values, err := c.prepareSecretValues(ctx, c.config, name, values)
+	if err != nil {
+		return err
+	}
+	if err := c.config.expandMergedTemplates(values); err != nil {
+		return err
+	}
+
+	// Set the valid lines, even when malformed lines were collected
+	if _, err := c.config.setEnvValues(values); err != nil {
+		return err
+	}
+
+	return parseErr
+}
+
+// ErrMultipleSecrets is returned by the operations that read a single secret
+// when SecretName lists several comma-separated secrets.
+var ErrMultipleSecrets = errors.New("operation does not support a comma-separated SecretName")
+
+// allSecretNames returns SecretName, or every name of a comma-separated
+// SecretName, followed by SecretNames.
+func (c *Config) allSecretNames() []string {
+	names := make([]string, 0, len(c.mergedSecretNames)+len(c.SecretNames)+1)
+	switch {
+	case len(c.mergedSecretNames) > 0:
+		names = append(names, c.mergedSecretNames...)
+	case c.SecretName != "":
+		names = append(names, c.SecretName)
+	}
+	return append(names, c.SecretNames...)
+}
+
+// splitSecretName splits a comma-separated SecretName, keeping the first
+// name as SecretName and every name in mergedSecretNames. Blank names are
+// dropped, and a single name is left as is.
+func (c *Config) splitSecretName() {
+	if !strings.Contains(c.SecretName, ",") {
+		return
+	}
+
+	var names []string
+	for _, name := range strings.Split(c.SecretName, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	c.SecretName, c.mergedSecretNames = "", nil
+	if len(names) > 0 {
+		c.SecretName = names[0]
+	}
+	if len(names) > 1 {
+		c.mergedSecretNames = names
+	}
+}
+
+// SecretRef identifies a secret version to load.
+type SecretRef struct {
+	// Name is the short name of the secret. Defaults to Config.SecretName when empty.
+	Name string
+	// Version is the version or alias of the secret. Defaults to
+	// Config.SecretVersion when empty.
+	Version string
+}
+
+// LoadMergedSecretsToEnv retrieves and parses every referenced secret
+// version, merges them in order and sets the result as environment
+// variables. The versions are retrieved with at most Config.MaxConcurrency
+// calls at once. Merging is last-wins: a key present in a later reference
+// overrides the same key of an earlier one, including when the later value
+// is empty, which allows an override to blank a base value.
+//
+// Nothing is set when any reference fails to load, regardless of ContinueOnError.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - refs: The secret versions to merge, from base to highest precedence.
+//
+// Returns:
+// - An error if any secret retrieval, parsing, or environment variable setting fails.
+func (c *Client) LoadMergedSecretsToEnv(ctx context.Context, refs []SecretRef) error {
+	resolved := make([]SecretRef, len(refs))
+	for i, ref := range refs {
+		name, version := c.config.resolveRef(ref)
+		if err := validateVersion(version); err != nil {
+			return fmt.Errorf("secret %s: %w", name, err)
+		}
+		resolved[i] = SecretRef{Name: name, Version: version}
+	}
+	results := c.accessAll(ctx, resolved, true)
+
+	merged := make(map[string]string)
+	for i, ref := range resolved {
+		result := results[i]
+		if result.err != nil {
+			return fmt.Errorf("secret %s version %s: failed to retrieve secret: %w", ref.Name, ref.Version, result.err)
+		}
+
+		values, err := c.config.parseSecret(string(result.response.Payload.Data), nil)
+		if err != nil {
+			return fmt.Errorf("secret %s version %s: failed to parse secret: %w", ref.Name, ref.Version, err)
+		}
+		values, err = c.prepareSecretValues(ctx, c.config, ref.Name, values)
+		if err != nil {
+			return fmt.Errorf("secret %s version %s: %w", ref.Name, ref.Version, err)
+		}
+		for key, value := range values {
+			merged[key] = value
+		}
+	}
+
+	if err := c.config.expandMergedTemplates(merged); err != nil {
+		return err
+	}
+
+	_, err := c.config.setEnvValues(merged)
+	return err
+}
+
+// resolveRef fills the empty fields of ref from the configured secret.
+func (c *Config) resolveRef(ref SecretRef) (string, string) {
+	name, version := ref.Name, ref.Version
+	if name == "" {
+		name = c.SecretName
+	}
+	if version == "" {
+		version = c.SecretVersion
+	}
+	return name, version
+}
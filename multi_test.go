@@ -0,0 +1,243 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// namedMockClient returns a payload per secret name and NotFound for unknown names.
+type namedMockClient struct {
+	mockSecretManagerClient
+	payloads map[string]string
+	accessed []string
+}
+
+func (m *namedMockClient) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	m.accessed = append(m.accessed, req.Name)
+	for name, payload := range m.payloads {
+		if strings.Contains(req.Name, "/secrets/"+name+"/") {
+			return &secretmanagerpb.AccessSecretVersionResponse{
+				Payload: &secretmanagerpb.SecretPayload{Data: []byte(payload)},
+			}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "not found")
+}
+
+func TestLoadAllSecretsToEnv(t *testing.T) {
+	payloads := map[string]string{
+		"first":  "MULTI_FIRST=1",
+		"second": "MULTI_SECOND=2",
+	}
+
+	testCases := []struct {
+		name             string
+		config           *Config
+		expectedAccessed int
+		expectedEnv      map[string]string
+		expectedErr      string
+	}{
+		{
+			name: "success load every secret",
+			config: &Config{
+				ProjectID:     "p",
+				SecretName:    "first",
+				SecretNames:   []string{"second"},
+				SecretVersion: "latest",
+			},
+			expectedAccessed: 2,
+			expectedEnv:      map[string]string{"MULTI_FIRST": "1", "MULTI_SECOND": "2"},
+		},
+		{
+			name: "fail fast on first error",
+			config: &Config{
+				ProjectID:   "p",
+				SecretNames: []string{"missing", "second"},
+			},
+			expectedAccessed: 1,
+			expectedEnv:      map[string]string{"MULTI_SECOND": ""},
+			expectedErr:      "secret missing",
+		},
+		{
+			name: "continue and aggregate errors",
+			config: &Config{
+				ProjectID:       "p",
+				SecretNames:     []string{"missing", "second", "absent"},
+				ContinueOnError: true,
+			},
+			expectedAccessed: 3,
+			expectedEnv:      map[string]string{"MULTI_SECOND": "2"},
+			expectedErr:      "secret absent",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("MULTI_FIRST", "")
+			t.Setenv("MULTI_SECOND", "")
+
+			mock := &namedMockClient{payloads: payloads}
+			c := &Client{client: mock, config: tc.config}
+
+			err := c.LoadAllSecretsToEnv(context.Background())
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, mock.accessed, tc.expectedAccessed)
+			for key, value := range tc.expectedEnv {
+				assert.Equal(t, value, os.Getenv(key))
+			}
+		})
+	}
+}
+
+func TestLoadMergedSecretsToEnv(t *testing.T) {
+	payloads := map[string]string{
+		"base":     "MERGE_HOST=base-host\nMERGE_PORT=5432\nMERGE_DEBUG=true\n",
+		"override": "MERGE_HOST=override-host\nMERGE_DEBUG=\n",
+	}
+
+	testCases := []struct {
+		name        string
+		refs        []SecretRef
+		expectedEnv map[string]string
+		expectedErr string
+	}{
+		{
+			name: "last wins including empty values",
+			refs: []SecretRef{{Name: "base"}, {Name: "override", Version: "2"}},
+			expectedEnv: map[string]string{
+				"MERGE_HOST":  "override-host",
+				"MERGE_PORT":  "5432",
+				"MERGE_DEBUG": "",
+			},
+		},
+		{
+			name: "order defines precedence",
+			refs: []SecretRef{{Name: "override"}, {Name: "base"}},
+			expectedEnv: map[string]string{
+				"MERGE_HOST":  "base-host",
+				"MERGE_PORT":  "5432",
+				"MERGE_DEBUG": "true",
+			},
+		},
+		{
+			name:        "fail without setting anything",
+			refs:        []SecretRef{{Name: "base"}, {Name: "missing"}},
+			expectedEnv: map[string]string{"MERGE_HOST": "unset"},
+			expectedErr: "secret missing version latest",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("MERGE_HOST", "unset")
+			t.Setenv("MERGE_PORT", "unset")
+			t.Setenv("MERGE_DEBUG", "unset")
+
+			mock := &namedMockClient{payloads: payloads}
+			c := &Client{client: mock, config: &Config{ProjectID: "p", SecretName: "base", SecretVersion: "latest"}}
+
+			err := c.LoadMergedSecretsToEnv(context.Background(), tc.refs)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			for key, value := range tc.expectedEnv {
+				assert.Equal(t, value, os.Getenv(key))
+			}
+		})
+	}
+}
+
+func TestLoadSecretToEnvCommaSeparatedNames(t *testing.T) {
+	payloads := map[string]string{
+		"base":     "LIST_HOST=base-host\nLIST_PORT=5432\n",
+		"override": "LIST_HOST=override-host\n",
+	}
+
+	testCases := []struct {
+		name             string
+		secretName       string
+		expectedFirst    string
+		expectedCount    int
+		expectedAccessed int
+		expectedEnv      map[string]string
+		expectedErr      string
+	}{
+		{
+			name:             "single name",
+			secretName:       "base",
+			expectedFirst:    "base",
+			expectedCount:    2,
+			expectedAccessed: 1,
+			expectedEnv:      map[string]string{"LIST_HOST": "base-host", "LIST_PORT": "5432"},
+		},
+		{
+			name:             "later secrets win",
+			secretName:       "base, override",
+			expectedFirst:    "base",
+			expectedCount:    2,
+			expectedAccessed: 2,
+			expectedEnv:      map[string]string{"LIST_HOST": "override-host", "LIST_PORT": "5432"},
+		},
+		{
+			name:             "order decides the winner",
+			secretName:       "override,base",
+			expectedFirst:    "override",
+			expectedCount:    2,
+			expectedAccessed: 2,
+			expectedEnv:      map[string]string{"LIST_HOST": "base-host", "LIST_PORT": "5432"},
+		},
+		{
+			name:             "blank names dropped",
+			secretName:       "base,,",
+			expectedFirst:    "base",
+			expectedCount:    2,
+			expectedAccessed: 1,
+			expectedEnv:      map[string]string{"LIST_HOST": "base-host", "LIST_PORT": "5432"},
+		},
+		{
+			name:             "missing secret sets nothing",
+			secretName:       "base,absent",
+			expectedFirst:    "base",
+			expectedAccessed: 2,
+			expectedEnv:      map[string]string{"LIST_HOST": "", "LIST_PORT": ""},
+			expectedErr:      "secret absent: failed to retrieve secret",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("LIST_HOST", "")
+			t.Setenv("LIST_PORT", "")
+
+			mock := &namedMockClient{payloads: payloads}
+			c := NewClient(mock, &Config{ProjectID: "p", SecretName: tc.secretName})
+			assert.Equal(t, tc.expectedFirst, c.config.SecretName)
+
+			count, err := c.LoadSecretToEnvCount(context.Background())
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Len(t, mock.accessed, tc.expectedAccessed)
+			for key, value := range tc.expectedEnv {
+				assert.Equal(t, value, os.Getenv(key))
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"strings"
+)
+
+// secretNamespace returns the key prefix derived from a secret name: the name
+// upper-cased, with every character other than letters, digits and '_'
+// replaced by '_', followed by '_'. A leading digit is preceded by '_' so the
+// prefixed keys remain valid environment variable names.
+func secretNamespace(secretName string) string {
+	var b strings.Builder
+	for i, r := range strings.ToUpper(secretName) {
+		switch {
+		case r >= 'A' && r <= 'Z', r == '_':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	b.WriteByte('_')
+	return b.String()
+}
+
+// namespaceValues returns values with every key prefixed by the namespace of
+// secretName when PrefixWithSecretName is set, and values itself otherwise.
+func (c *Config) namespaceValues(secretName string, values map[string]string) map[string]string {
+	if !c.PrefixWithSecretName || values == nil {
+		return values
+	}
+
+	namespace := secretNamespace(secretName)
+	namespaced := make(map[string]string, len(values))
+	for key, value := range values {
+		namespaced[namespace+key] = value
+	}
+	return namespaced
+}
+
+// namespaceEntries prefixes the key of every entry with the namespace of
+// secretName when PrefixWithSecretName is set.
+func (c *Config) namespaceEntries(secretName string, entries []secretEntry) {
+	if !c.PrefixWithSecretName {
+		return
+	}
+
+	namespace := secretNamespace(secretName)
+	for i := range entries {
+		entries[i].Key = namespace + entries[i].Key
+	}
+}
+
+// prepareSecretValues resolves the references of the values parsed from
+// secretName and, when PrefixWithSecretName is set, expands their templates
+// and prefixes their keys with the namespace of secretName. Templates thus
+// reference the keys as written in their own secret. Without a namespace,
+// templates are left to expandMergedTemplates, so that merged secrets may
+// reference each other.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - cfg: The configuration used to prepare the values.
+// - secretName: The name of the secret the values were parsed from.
+// - values: The parsed values, updated in place.
+//
+// Returns:
+// - The prepared values, namespaced when PrefixWithSecretName is set.
+// - An error if a reference cannot be resolved or a template expanded.
+func (c *Client) prepareSecretValues(ctx context.Context, cfg *Config, secretName string, values map[string]string) (map[string]string, error) {
+	if err := c.resolveReferences(ctx, cfg, values); err != nil {
+		return nil, err
+	}
+	if !cfg.PrefixWithSecretName {
+		return values, nil
+	}
+	if err := cfg.expandTemplates(values); err != nil {
+		return nil, err
+	}
+	return cfg.namespaceValues(secretName, values), nil
+}
+
+// expandMergedTemplates expands the templates of values prepared by
+// prepareSecretValues, once merged, when they are not namespaced.
+func (c *Config) expandMergedTemplates(values map[string]string) error {
+	if c.PrefixWithSecretName {
+		return nil
+	}
+	return c.expandTemplates(values)
+}
@@ -0,0 +1,180 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretNamespace(t *testing.T) {
+	testCases := []struct {
+		secretName string
+		expected   string
+	}{
+		{secretName: "billing-db", expected: "BILLING_DB_"},
+		{secretName: "Payments.API_v2", expected: "PAYMENTS_API_V2_"},
+		{secretName: "2fa-keys", expected: "_2FA_KEYS_"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.secretName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, secretNamespace(tc.secretName))
+		})
+	}
+}
+
+func TestLoadAllSecretsToEnvPrefixWithSecretName(t *testing.T) {
+	for _, key := range []string{"BILLING_DB_PASSWORD", "USERS_DB_PASSWORD", "SVC_BILLING_DB_PASSWORD", "PASSWORD"} {
+		t.Setenv(key, "")
+	}
+
+	payloads := map[string]string{
+		"billing-db": "PASSWORD=billing\n",
+		"users-db":   "PASSWORD=users\n",
+	}
+
+	c := &Client{
+		client: &namedMockClient{payloads: payloads},
+		config: &Config{
+			ProjectID:            "p",
+			SecretNames:          []string{"billing-db", "users-db"},
+			PrefixWithSecretName: true,
+		},
+	}
+	assert.NoError(t, c.LoadAllSecretsToEnv(context.Background()))
+	assert.Equal(t, "billing", os.Getenv("BILLING_DB_PASSWORD"))
+	assert.Equal(t, "users", os.Getenv("USERS_DB_PASSWORD"))
+	assert.Equal(t, "", os.Getenv("PASSWORD"))
+
+	// The namespace also keeps a comma-separated SecretName apart, after KeyPrefix
+	os.Setenv("BILLING_DB_PASSWORD", "")
+	c = NewClient(&namedMockClient{payloads: payloads}, &Config{
+		ProjectID:            "p",
+		SecretName:           "billing-db,users-db",
+		KeyPrefix:            "SVC_",
+		PrefixWithSecretName: true,
+	})
+	assert.NoError(t, c.LoadSecretToEnv(context.Background()))
+	assert.Equal(t, "billing", os.Getenv("SVC_BILLING_DB_PASSWORD"))
+	assert.Equal(t, "", os.Getenv("BILLING_DB_PASSWORD"))
+}
+
+func TestPrefixWithSecretNameExpandsOwnKeys(t *testing.T) {
+	payloads := map[string]string{
+		"billing-db": "USER=billing\nURL=${USER}@host\n",
+		"users-db":   "USER=users\nURL=${USER}@host\n",
+	}
+
+	testCases := []struct {
+		name     string
+		config   Config
+		load     func(c *Client) error
+		expected map[string]string
+	}{
+		{
+			name:   "single secret",
+			config: Config{SecretName: "billing-db"},
+			load: func(c *Client) error {
+				return c.LoadSecretToEnv(context.Background())
+			},
+			expected: map[string]string{"BILLING_DB_URL": "billing@host"},
+		},
+		{
+			name:   "comma-separated secret names",
+			config: Config{SecretName: "billing-db,users-db"},
+			load: func(c *Client) error {
+				return c.LoadSecretToEnv(context.Background())
+			},
+			expected: map[string]string{"BILLING_DB_URL": "billing@host", "USERS_DB_URL": "users@host"},
+		},
+		{
+			name:   "selected keys",
+			config: Config{SecretName: "billing-db,users-db"},
+			load: func(c *Client) error {
+				return c.LoadSelectedSecretsToEnv(context.Background(), []string{"USERS_DB_URL"})
+			},
+			expected: map[string]string{"BILLING_DB_URL": "", "USERS_DB_URL": "users@host"},
+		},
+		{
+			name:   "all secrets",
+			config: Config{SecretNames: []string{"billing-db", "users-db"}},
+			load: func(c *Client) error {
+				return c.LoadAllSecretsToEnv(context.Background())
+			},
+			expected: map[string]string{"BILLING_DB_URL": "billing@host", "USERS_DB_URL": "users@host"},
+		},
+		{
+			name:   "merged secrets",
+			config: Config{},
+			load: func(c *Client) error {
+				return c.LoadMergedSecretsToEnv(context.Background(), []SecretRef{{Name: "billing-db"}, {Name: "users-db"}})
+			},
+			expected: map[string]string{"BILLING_DB_URL": "billing@host", "USERS_DB_URL": "users@host"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"BILLING_DB_USER", "BILLING_DB_URL", "USERS_DB_USER", "USERS_DB_URL"} {
+				t.Setenv(key, "")
+			}
+
+			tc.config.ProjectID = "p"
+			tc.config.PrefixWithSecretName = true
+			tc.config.ExpandTemplates = true
+			c := NewClient(&namedMockClient{payloads: payloads}, &tc.config)
+
+			assert.NoError(t, tc.load(c))
+			for key, value := range tc.expected {
+				assert.Equal(t, value, os.Getenv(key), key)
+			}
+		})
+	}
+}
+
+func TestExportAsShellPrefixWithSecretNameExpandsOwnKeys(t *testing.T) {
+	c := NewClient(&namedMockClient{payloads: map[string]string{
+		"billing-db": "USER=billing\nURL=${USER}@host\n",
+	}}, &Config{ProjectID: "p", SecretName: "billing-db", PrefixWithSecretName: true, ExpandTemplates: true})
+
+	var out strings.Builder
+	assert.NoError(t, c.ExportAsShell(context.Background(), &out))
+	assert.Contains(t, out.String(), "export BILLING_DB_URL='billing@host'\n")
+}
+
+func TestRequiredKeysPrefixWithSecretName(t *testing.T) {
+	testCases := []struct {
+		name    string
+		keys    []string
+		missing []string
+	}{
+		{name: "namespaced key", keys: []string{"BILLING_DB_PASSWORD"}},
+		{name: "key as written in the secret", keys: []string{"PASSWORD"}, missing: []string{"PASSWORD"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("BILLING_DB_PASSWORD", "")
+			cfg := &Config{ProjectID: "p", SecretName: "billing-db", PrefixWithSecretName: true}
+			c := NewClient(&namedMockClient{payloads: map[string]string{"billing-db": "PASSWORD=billing\n"}}, cfg)
+
+			// RequireKeys and RequiredKeys agree on the form of the keys
+			requireErr := c.RequireKeys(context.Background(), tc.keys)
+			c.config.RequiredKeys = tc.keys
+			loadErr := c.LoadSecretToEnv(context.Background())
+
+			for _, err := range []error{requireErr, loadErr} {
+				if tc.missing == nil {
+					assert.NoError(t, err)
+					continue
+				}
+				var missing MissingKeysError
+				assert.ErrorAs(t, err, &missing)
+				assert.Equal(t, tc.missing, missing.Keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/api/option"
+)
+
+// Environment variables read by New for the settings not given as options.
+const (
+	// ProjectIDEnv holds the Google Cloud project ID.
+	ProjectIDEnv = "GCP_PROJECT_ID"
+	// SecretNameEnv holds the name of the secret, or a comma-separated list
+	// of names merged by LoadSecretToEnv as described on Config.SecretName.
+	SecretNameEnv = "SECRET_NAME"
+	// SecretVersionEnv holds the version of the secret.
+	SecretVersionEnv = "SECRET_VERSION"
+)
+
+// Option configures a Client created by New.
+type Option func(*newOptions)
+
+// newOptions collects the settings applied by the options of New.
+type newOptions struct {
+	config     Config
+	clientOpts []option.ClientOption
+}
+
+// WithProjectID sets the Google Cloud project ID.
+func WithProjectID(projectID string) Option {
+	return func(o *newOptions) { o.config.ProjectID = projectID }
+}
+
+// WithSecretName sets the name of the secret.
+func WithSecretName(name string) Option {
+	return func(o *newOptions) { o.config.SecretName = name }
+}
+
+// WithVersion sets the version or alias of the secret.
+func WithVersion(version string) Option {
+	return func(o *newOptions) { o.config.SecretVersion = version }
+}
+
+// WithTimeout sets the timeout of each Secret Manager call.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *newOptions) { o.config.Timeout = timeout }
+}
+
+// WithRetry sets the retry policy of transient failures.
+func WithRetry(retry RetryConfig) Option {
+	return func(o *newOptions) { o.config.Retry = retry }
+}
+
+// WithLogger sets the logger used by the Client.
+func WithLogger(logger *zerolog.Logger) Option {
+	return func(o *newOptions) { o.config.Logger = logger }
+}
+
+// WithUserAgent sets the User-Agent sent with Secret Manager requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *newOptions) { o.config.UserAgent = userAgent }
+}
+
+// WithConfig replaces every setting with cfg, for the options that have no
+// dedicated Option. Options given after it still apply on top of cfg.
+func WithConfig(cfg Config) Option {
+	return func(o *newOptions) { o.config = cfg }
+}
+
+// WithClientOptions adds options forwarded to the Secret Manager client, such
+// as option.WithCredentialsFile.
+func WithClientOptions(opts ...option.ClientOption) Option {
+	return func(o *newOptions) { o.clientOpts = append(o.clientOpts, opts...) }
+}
+
+// New initializes a new Secret Manager client from functional options.
+//
+// Settings are resolved in a fixed order:
+//  1. The options, applied in the order given, a later option overriding an
+//     earlier one setting the same field.
+//  2. For the project ID, secret name and secret version left empty, the
+//     GCP_PROJECT_ID, SECRET_NAME and SECRET_VERSION environment variables.
+//  3. The defaults and environment fallbacks of NewSecretWithConfig, such as
+//     the "latest" version and the SECRET_LOCATION variable.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - opts: The options configuring the client.
+//
+// Returns:
+// - A pointer to a Client struct representing the Secret Manager client.
+// - An error if the configuration is invalid or the client initialization fails,
+// as returned by NewSecretWithConfig.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	var o newOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// Fall back to the environment for the settings not given as options
+	if o.config.ProjectID == "" {
+		o.config.ProjectID = os.Getenv(ProjectIDEnv)
+	}
+	if o.config.SecretName == "" {
+		o.config.SecretName = os.Getenv(SecretNameEnv)
+	}
+	if o.config.SecretVersion == "" {
+		o.config.SecretVersion = os.Getenv(SecretVersionEnv)
+	}
+
+	return NewSecretWithConfig(ctx, &o.config, o.clientOpts...)
+}
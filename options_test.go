@@ -0,0 +1,113 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+)
+
+func TestNew(t *testing.T) {
+	originDefaultClientFactory := defaultClientFactory
+	defer func() {
+		defaultClientFactory = originDefaultClientFactory
+	}()
+
+	var received []option.ClientOption
+	defaultClientFactory = func(ctx context.Context, opts ...option.ClientOption) (SecretManagerClient, error) {
+		received = opts
+		return &mockSecretManagerClient{}, nil
+	}
+
+	logger := zerolog.Nop()
+	credentials := option.WithCredentialsFile("/path/to/credentials.json")
+
+	testCases := []struct {
+		name        string
+		env         map[string]string
+		opts        []Option
+		expected    Config
+		expectedErr error
+	}{
+		{
+			name: "options only",
+			opts: []Option{
+				WithProjectID("opt-project"),
+				WithSecretName("opt-secret"),
+				WithVersion("4"),
+				WithTimeout(time.Second),
+				WithRetry(RetryConfig{MaxAttempts: 3}),
+				WithLogger(&logger),
+				WithUserAgent("billing-service/1.2"),
+			},
+			expected: Config{
+				ProjectID:     "opt-project",
+				SecretName:    "opt-secret",
+				SecretVersion: "4",
+				Timeout:       time.Second,
+				Retry:         RetryConfig{MaxAttempts: 3},
+				Logger:        &logger,
+				UserAgent:     "billing-service/1.2",
+			},
+		},
+		{
+			name: "environment fallback",
+			env:  map[string]string{ProjectIDEnv: "env-project", SecretNameEnv: "env-secret", SecretVersionEnv: "2"},
+			opts: []Option{WithSecretName("opt-secret")},
+			expected: Config{
+				ProjectID:     "env-project",
+				SecretName:    "opt-secret",
+				SecretVersion: "2",
+			},
+		},
+		{
+			name: "later options win",
+			opts: []Option{
+				WithProjectID("first"),
+				WithConfig(Config{ProjectID: "config-project", SecretName: "config-secret", LooseKeys: true}),
+				WithVersion("7"),
+			},
+			expected: Config{
+				ProjectID:     "config-project",
+				SecretName:    "config-secret",
+				SecretVersion: "7",
+				LooseKeys:     true,
+			},
+		},
+		{
+			name:     "default version",
+			opts:     []Option{WithProjectID("p"), WithSecretName("s")},
+			expected: Config{ProjectID: "p", SecretName: "s", SecretVersion: LatestVersion},
+		},
+		{
+			name:        "missing project",
+			opts:        []Option{WithSecretName("s")},
+			expectedErr: ConfigError{MissingField: "GCP_PROJECT_ID"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{ProjectIDEnv, SecretNameEnv, SecretVersionEnv, LocalFileEnv, LocationEnv} {
+				t.Setenv(key, tc.env[key])
+			}
+
+			received = nil
+			client, err := New(context.Background(), append(tc.opts, WithClientOptions(credentials))...)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, *client.config)
+			expectedOpts := []option.ClientOption{credentials}
+			if tc.expected.UserAgent != "" {
+				expectedOpts = append([]option.ClientOption{option.WithUserAgent(tc.expected.UserAgent)}, expectedOpts...)
+			}
+			assert.Equal(t, expectedOpts, received)
+		})
+	}
+}
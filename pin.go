@@ -0,0 +1,54 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+)
+
+// secretVersion returns the version of the configured secret to read. When
+// PinLatest is set and the configured version is "latest", it is resolved to
+// the concrete version on the first call, which is then returned by every
+// later call.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - The version to read.
+// - An error if resolving the latest version fails.
+func (c *Client) secretVersion(ctx context.Context) (string, error) {
+	if !c.config.PinLatest || c.config.SecretVersion != LatestVersion {
+		return c.config.SecretVersion, nil
+	}
+
+	c.mu.Lock()
+	pinned := c.pinnedVersion
+	c.mu.Unlock()
+	if pinned != "" {
+		return pinned, nil
+	}
+
+	info, err := c.GetSecretVersionInfo(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to pin latest version: %w", err)
+	}
+	if info.Version == "" {
+		return "", fmt.Errorf("failed to pin latest version: no version in %q", info.Name)
+	}
+
+	// Keep the version pinned by a concurrent call, if any
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pinnedVersion == "" {
+		c.pinnedVersion = info.Version
+	}
+	return c.pinnedVersion, nil
+}
+
+// PinnedVersion returns the version "latest" was pinned to when PinLatest is
+// set, or an empty string if it has not been resolved yet.
+func (c *Client) PinnedVersion() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pinnedVersion
+}
@@ -0,0 +1,79 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinLatest(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        Config
+		expectedPaths []string
+		expectedPin   string
+	}{
+		{
+			name:   "pinned latest",
+			config: Config{ProjectID: "p", SecretName: "s", SecretVersion: LatestVersion, PinLatest: true},
+			expectedPaths: []string{
+				"projects/p/secrets/s/versions/7",
+				"projects/p/secrets/s/versions/7",
+			},
+			expectedPin: "7",
+		},
+		{
+			name:   "latest without pinning",
+			config: Config{ProjectID: "p", SecretName: "s", SecretVersion: LatestVersion},
+			expectedPaths: []string{
+				"projects/p/secrets/s/versions/latest",
+				"projects/p/secrets/s/versions/latest",
+			},
+		},
+		{
+			name:   "explicit version is not pinned",
+			config: Config{ProjectID: "p", SecretName: "s", SecretVersion: "3", PinLatest: true},
+			expectedPaths: []string{
+				"projects/p/secrets/s/versions/3",
+				"projects/p/secrets/s/versions/3",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := &nameRecorder{mockSecretManagerClient: mockSecretManagerClient{
+				secretPayload: "KEY=value",
+				isSuccess:     true,
+				version:       &secretmanagerpb.SecretVersion{Name: "projects/p/secrets/s/versions/7"},
+			}}
+			c := &Client{client: recorder, config: &tc.config}
+
+			for range 2 {
+				_, err := c.GetSecret(context.Background())
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedPaths, recorder.names)
+			assert.Equal(t, tc.expectedPin, c.PinnedVersion())
+		})
+	}
+}
+
+func TestPinLatestError(t *testing.T) {
+	c := &Client{
+		client: &mockSecretManagerClient{errs: []error{fmt.Errorf("metadata error")}, isSuccess: true},
+		config: &Config{ProjectID: "p", SecretName: "s", SecretVersion: LatestVersion, PinLatest: true},
+	}
+
+	_, err := c.GetSecret(context.Background())
+	assert.ErrorContains(t, err, "failed to pin latest version")
+	assert.Empty(t, c.PinnedVersion())
+
+	// The next read retries the resolution
+	_, err = c.GetSecret(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "1", c.PinnedVersion())
+}
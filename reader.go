@@ -0,0 +1,80 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+)
+
+// staticClient serves fixed secret content, whatever the requested secret
+// name and version.
+type staticClient struct {
+	data []byte
+}
+
+// AccessSecretVersion returns the static content.
+func (s *staticClient) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	return &secretmanagerpb.AccessSecretVersionResponse{
+		Name: req.Name,
+		Payload: &secretmanagerpb.SecretPayload{
+			Data: s.data,
+		},
+	}, nil
+}
+
+// GetSecretVersion reports the static content as an enabled version.
+func (s *staticClient) GetSecretVersion(ctx context.Context, req *secretmanagerpb.GetSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	return &secretmanagerpb.SecretVersion{
+		Name:  req.Name,
+		State: secretmanagerpb.SecretVersion_ENABLED,
+	}, nil
+}
+
+// Close is a no-op for static content.
+func (s *staticClient) Close() error {
+	return nil
+}
+
+// NewClientFromReader creates a Client serving the content read from r
+// instead of calling Secret Manager, for tests or for secrets sourced
+// elsewhere, such as another secret store. Every read method returns that
+// content, parsed with the rules of cfg; ProjectID and SecretName are not
+// required.
+//
+// Parameters:
+// - r: The reader of the secret content, in KEY=VALUE format. It is read
+// entirely before NewClientFromReader returns.
+// - cfg: The configuration of the client, may be nil.
+//
+// Returns:
+// - A pointer to a Client struct serving the content.
+// - An error if reading r fails.
+func NewClientFromReader(r io.Reader, cfg *Config) (*Client, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret content: %w", err)
+	}
+
+	return NewClient(&staticClient{data: data}, cfg), nil
+}
+
+// LoadFromReader reads KEY=VALUE content from r and sets it as environment
+// variables with the same rules as LoadSecretToEnv, without calling Secret
+// Manager.
+//
+// Parameters:
+// - r: The reader of the secret content.
+// - cfg: The configuration controlling parsing and loading, may be nil.
+//
+// Returns:
+// - An error if reading, parsing, or environment variable setting fails.
+func LoadFromReader(r io.Reader, cfg *Config) error {
+	c, err := NewClientFromReader(r, cfg)
+	if err != nil {
+		return err
+	}
+	return c.LoadSecretToEnv(context.Background())
+}
@@ -0,0 +1,72 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFromReader(t *testing.T) {
+	testCases := []struct {
+		name        string
+		reader      func() *strings.Reader
+		config      *Config
+		expectedEnv map[string]string
+		errContains string
+	}{
+		{
+			name:        "nil config",
+			reader:      func() *strings.Reader { return strings.NewReader("# vault shim\nREADER_A=1\nREADER_B=\"two words\"\n") },
+			expectedEnv: map[string]string{"READER_A": "1", "READER_B": "two words"},
+		},
+		{
+			name:        "config rules applied",
+			reader:      func() *strings.Reader { return strings.NewReader("a=1\nb=2\n") },
+			config:      &Config{KeyPrefix: "READER_", NormalizeKeysUpper: true, KeyFilter: AllowKeys("A")},
+			expectedEnv: map[string]string{"READER_A": "1", "READER_B": ""},
+		},
+		{
+			name:        "parse error",
+			reader:      func() *strings.Reader { return strings.NewReader("READER_A=1\nbroken\n") },
+			expectedEnv: map[string]string{"READER_A": ""},
+			errContains: "invalid format at line 2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("READER_A", "")
+			t.Setenv("READER_B", "")
+
+			err := LoadFromReader(tc.reader(), tc.config)
+			if tc.errContains != "" {
+				assert.ErrorContains(t, err, tc.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			for key, value := range tc.expectedEnv {
+				assert.Equal(t, value, os.Getenv(key))
+			}
+		})
+	}
+}
+
+func TestNewClientFromReader(t *testing.T) {
+	c, err := NewClientFromReader(strings.NewReader("B=2\nA=1\n"), nil)
+	assert.NoError(t, err)
+
+	keys, err := c.ValidateSecret(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B", "A"}, keys)
+
+	secret, err := c.GetSecret(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "B=2\nA=1\n", secret)
+
+	_, err = NewClientFromReader(iotest.ErrReader(os.ErrClosed), nil)
+	assert.ErrorContains(t, err, "failed to read secret content")
+}
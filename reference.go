@@ -0,0 +1,86 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ReferencePrefix marks a value that refers to another secret version, as in
+// "ref:projects/P/secrets/S/versions/V".
+const ReferencePrefix = "ref:"
+
+// MaxReferenceDepth is the maximum number of references followed to resolve
+// a single value.
+const MaxReferenceDepth = 5
+
+// ErrReferenceCycle is returned when following references leads back to a
+// secret version already visited.
+var ErrReferenceCycle = errors.New("secret reference cycle")
+
+// ErrReferenceDepth is returned when resolving a value requires following
+// more than MaxReferenceDepth references.
+var ErrReferenceDepth = errors.New("secret reference depth exceeded")
+
+// resolveReferences replaces every reference in values with the payload it
+// points to when cfg.ResolveReferences is set.
+func (c *Client) resolveReferences(ctx context.Context, cfg *Config, values map[string]string) error {
+	if !cfg.ResolveReferences {
+		return nil
+	}
+
+	for key, value := range values {
+		resolved, err := c.resolveReference(ctx, key, value)
+		if err != nil {
+			return err
+		}
+		values[key] = resolved
+	}
+	return nil
+}
+
+// resolveReference returns the payload value refers to, following nested
+// references, or value itself if it is not a reference.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - key: The key holding value, used in error messages.
+// - value: The value to resolve.
+//
+// Returns:
+// - The resolved value.
+// - An error wrapping ErrInvalidResourceName, ErrReferenceCycle or
+// ErrReferenceDepth, or the retrieval error, naming the key and the chain
+// of references followed so far. Values are never included.
+func (c *Client) resolveReference(ctx context.Context, key, value string) (string, error) {
+	var chain []string
+	for strings.HasPrefix(value, ReferencePrefix) {
+		name := strings.TrimSpace(strings.TrimPrefix(value, ReferencePrefix))
+		for _, visited := range chain {
+			if visited == name {
+				return "", fmt.Errorf("key %s: %w: %s", key, ErrReferenceCycle, c.referenceChain(append(chain, name)))
+			}
+		}
+		chain = append(chain, name)
+		if len(chain) > MaxReferenceDepth {
+			return "", fmt.Errorf("key %s: %w: more than %d references in %s", key, ErrReferenceDepth, MaxReferenceDepth, c.referenceChain(chain))
+		}
+
+		resolved, err := c.GetSecretByName(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("key %s: failed to resolve reference %s: %w", key, c.referenceChain(chain), err)
+		}
+		value = resolved
+	}
+	return value, nil
+}
+
+// referenceChain formats the followed references for error messages.
+func (c *Client) referenceChain(chain []string) string {
+	names := make([]string, len(chain))
+	for i, name := range chain {
+		names[i] = c.config.errorName(name)
+	}
+	return strings.Join(names, " -> ")
+}
@@ -0,0 +1,106 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSecretToEnvResolveReferences(t *testing.T) {
+	const main = "projects/p/secrets/main/versions/latest"
+	payloads := map[string]string{
+		"projects/p/secrets/shared/versions/latest": "shared-password",
+		"projects/p/secrets/alias/versions/1":       "ref:projects/p/secrets/shared/versions/latest",
+		"projects/p/secrets/loop-a/versions/1":      "ref:projects/p/secrets/loop-b/versions/1",
+		"projects/p/secrets/loop-b/versions/1":      "ref:projects/p/secrets/loop-a/versions/1",
+	}
+	for i := 0; i <= MaxReferenceDepth; i++ {
+		payloads[deepReference(i)] = "ref:" + deepReference(i+1)
+	}
+
+	testCases := []struct {
+		name          string
+		payload       string
+		disabled      bool
+		expectedValue string
+		expectedErr   error
+		errContains   string
+	}{
+		{
+			name:          "direct reference",
+			payload:       "REF_VALUE=ref:projects/p/secrets/shared/versions/latest\n",
+			expectedValue: "shared-password",
+		},
+		{
+			name:          "nested reference",
+			payload:       "REF_VALUE=ref:projects/p/secrets/alias/versions/1\n",
+			expectedValue: "shared-password",
+		},
+		{
+			name:          "plain value",
+			payload:       "REF_VALUE=plain\n",
+			expectedValue: "plain",
+		},
+		{
+			name:          "resolution disabled",
+			payload:       "REF_VALUE=ref:projects/p/secrets/shared/versions/latest\n",
+			disabled:      true,
+			expectedValue: "ref:projects/p/secrets/shared/versions/latest",
+		},
+		{
+			name:        "cycle",
+			payload:     "REF_VALUE=ref:projects/p/secrets/loop-a/versions/1\n",
+			expectedErr: ErrReferenceCycle,
+			errContains: "key REF_VALUE: secret reference cycle: projects/p/secrets/loop-a/versions/1 -> projects/p/secrets/loop-b/versions/1 -> projects/p/secrets/loop-a/versions/1",
+		},
+		{
+			name:        "too deep",
+			payload:     "REF_VALUE=ref:" + deepReference(0) + "\n",
+			expectedErr: ErrReferenceDepth,
+		},
+		{
+			name:        "malformed reference",
+			payload:     "REF_VALUE=ref:secrets/shared\n",
+			expectedErr: ErrInvalidResourceName,
+			errContains: "key REF_VALUE: failed to resolve reference secrets/shared",
+		},
+		{
+			name:        "missing reference",
+			payload:     "REF_VALUE=ref:projects/p/secrets/absent/versions/1\n",
+			expectedErr: ErrSecretNotFound,
+			errContains: "key REF_VALUE: failed to resolve reference projects/p/secrets/absent/versions/1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("REF_VALUE", "")
+
+			all := map[string]string{main: tc.payload}
+			for name, payload := range payloads {
+				all[name] = payload
+			}
+			c := &Client{
+				client: &versionPayloadClient{payloads: all},
+				config: &Config{ProjectID: "p", SecretName: "main", SecretVersion: LatestVersion, ResolveReferences: !tc.disabled},
+			}
+
+			err := c.LoadSecretToEnv(context.Background())
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.ErrorContains(t, err, tc.errContains)
+				assert.NotContains(t, err.Error(), "shared-password")
+				assert.Equal(t, "", os.Getenv("REF_VALUE"))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, os.Getenv("REF_VALUE"))
+		})
+	}
+}
+
+func deepReference(i int) string {
+	return "projects/p/secrets/deep" + string(rune('a'+i)) + "/versions/1"
+}
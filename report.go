@@ -0,0 +1,56 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"sort"
+)
+
+// LoadReport summarizes what LoadSecretToEnvWithReport did, for example to
+// emit a structured startup log. It never holds secret values.
+type LoadReport struct {
+	// KeysSet is the number of environment variables set.
+	KeysSet int
+	// LinesSkipped is the number of empty and comment lines of the secret.
+	LinesSkipped int
+	// Overwritten lists, sorted, the variables that already existed in the
+	// environment and were replaced.
+	Overwritten []string
+	// Warnings describes each malformed line skipped when ContinueOnError is
+	// set, as "line N: reason", without the line content.
+	Warnings []string
+}
+
+// LoadSecretToEnvWithReport works like LoadSecretToEnv and also returns a
+// report of the keys set, the lines skipped, the variables overwritten and
+// the malformed lines ignored.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - The report, never nil, also describing what was done before an error.
+// - An error if the secret retrieval or environment variable setting fails.
+func (c *Client) LoadSecretToEnvWithReport(ctx context.Context) (*LoadReport, error) {
+	report := &LoadReport{}
+
+	// Record the variables that exist just before they are set
+	cfg := *c.config
+	onSet := cfg.OnSet
+	cfg.OnSet = func(key, value string) error {
+		if onSet != nil {
+			if err := onSet(key, value); err != nil {
+				return err
+			}
+		}
+		if _, exists := os.LookupEnv(key); exists {
+			report.Overwritten = append(report.Overwritten, key)
+		}
+		return nil
+	}
+
+	count, err := c.loadSecretToEnv(ctx, &cfg, report)
+	report.KeysSet = count
+	sort.Strings(report.Overwritten)
+	return report, err
+}
@@ -0,0 +1,119 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSecretToEnvWithReport(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      Config
+		payload     string
+		isSuccess   bool
+		expected    *LoadReport
+		errContains string
+	}{
+		{
+			name:      "keys set, skipped lines and overwritten variables",
+			payload:   "# header\nREPORT_NEW=1\n\nREPORT_EXISTING=2\n  # trailing comment\n",
+			isSuccess: true,
+			expected: &LoadReport{
+				KeysSet:      2,
+				LinesSkipped: 3,
+				Overwritten:  []string{"REPORT_EXISTING"},
+			},
+		},
+		{
+			name:      "malformed lines reported as warnings",
+			config:    Config{ContinueOnError: true},
+			payload:   "REPORT_NEW=1\nbroken-secret-line\n",
+			isSuccess: true,
+			expected: &LoadReport{
+				KeysSet:  1,
+				Warnings: []string{"line 2: line must contain a '=' character"},
+			},
+			errContains: "line 2",
+		},
+		{
+			name:      "skipped existing variables are not overwritten",
+			config:    Config{SkipExisting: true},
+			payload:   "REPORT_NEW=1\nREPORT_EXISTING=2\n",
+			isSuccess: true,
+			expected:  &LoadReport{KeysSet: 1},
+		},
+		{
+			name:        "retrieval failure",
+			expected:    &LoadReport{},
+			errContains: "failed to retrieve secret",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Unsetenv("REPORT_NEW")
+			t.Setenv("REPORT_EXISTING", "old")
+			t.Cleanup(func() { os.Unsetenv("REPORT_NEW") })
+
+			c := &Client{
+				client: &mockSecretManagerClient{secretPayload: tc.payload, isSuccess: tc.isSuccess},
+				config: &tc.config,
+			}
+
+			report, err := c.LoadSecretToEnvWithReport(context.Background())
+			if tc.errContains != "" {
+				assert.ErrorContains(t, err, tc.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, report)
+		})
+	}
+}
+
+func TestLoadSecretToEnvWithReportOnSet(t *testing.T) {
+	t.Setenv("REPORT_REJECTED", "")
+
+	errRejected := errors.New("rejected")
+	c := &Client{
+		client: &mockSecretManagerClient{secretPayload: "REPORT_REJECTED=1\n", isSuccess: true},
+		config: &Config{OnSet: func(key, value string) error { return errRejected }},
+	}
+
+	report, err := c.LoadSecretToEnvWithReport(context.Background())
+	assert.ErrorIs(t, err, errRejected)
+	assert.Equal(t, &LoadReport{}, report)
+}
+
+func TestLoadSecretToEnvWithReportConcurrent(t *testing.T) {
+	t.Setenv("REPORT_SHARED", "")
+
+	c := &Client{
+		client: &versionPayloadClient{payloads: map[string]string{
+			"projects/p/secrets/s/versions/latest": "# comment\nREPORT_SHARED=1\nbroken\n",
+		}},
+		config: &Config{ProjectID: "p", SecretName: "s", SecretVersion: LatestVersion, ContinueOnError: true},
+	}
+
+	// Every call gets its own report, even when the configuration is shared
+	var wg sync.WaitGroup
+	reports := make([]*LoadReport, 8)
+	for i := range reports {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			reports[i], _ = c.LoadSecretToEnvWithReport(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	for _, report := range reports {
+		assert.Equal(t, 1, report.LinesSkipped)
+		assert.Equal(t, []string{"line 3: line must contain a '=' character"}, report.Warnings)
+	}
+}
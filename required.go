@@ -0,0 +1,52 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MissingKeysError is returned when required keys are absent from a secret.
+type MissingKeysError struct {
+	// Keys lists every missing key, in the order they were required
+	Keys []string
+}
+
+// Error implements the error interface for MissingKeysError
+func (e MissingKeysError) Error() string {
+	return fmt.Sprintf("missing required keys in secret: %s", strings.Join(e.Keys, ", "))
+}
+
+// RequireKeys retrieves and parses the secret and checks that every given key
+// is present, without modifying the process environment. Keys are checked in
+// the same form as Config.RequiredKeys: namespaced when PrefixWithSecretName
+// is set, and before KeyPrefix is applied.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - keys: The keys that must be present in the secret.
+//
+// Returns:
+// - A MissingKeysError listing all missing keys.
+// - An error if the secret retrieval or parsing fails.
+func (c *Client) RequireKeys(ctx context.Context, keys []string) error {
+	values, err := c.GetSecretAsMap(ctx)
+	if err != nil {
+		return err
+	}
+	return checkRequiredKeys(values, keys)
+}
+
+// checkRequiredKeys returns a MissingKeysError naming the keys absent from values.
+func checkRequiredKeys(values map[string]string, keys []string) error {
+	var missing []string
+	for _, key := range keys {
+		if _, ok := values[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return MissingKeysError{Keys: missing}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireKeys(t *testing.T) {
+	c := &Client{
+		client: &mockSecretManagerClient{
+			secretPayload: "DB_URL=postgres://x\nAPI_KEY=\n",
+			isSuccess:     true,
+		},
+		config: &Config{},
+	}
+
+	assert.NoError(t, c.RequireKeys(context.Background(), []string{"DB_URL", "API_KEY"}))
+
+	err := c.RequireKeys(context.Background(), []string{"MISSING_A", "DB_URL", "MISSING_B"})
+	var missingErr MissingKeysError
+	assert.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, []string{"MISSING_A", "MISSING_B"}, missingErr.Keys)
+	assert.EqualError(t, err, "missing required keys in secret: MISSING_A, MISSING_B")
+}
+
+func TestLoadSecretToEnvRequiredKeys(t *testing.T) {
+	t.Setenv("REQUIRED_PRESENT", "")
+	os.Unsetenv("REQUIRED_PRESENT")
+
+	c := &Client{
+		client: &mockSecretManagerClient{
+			secretPayload: "REQUIRED_PRESENT=1",
+			isSuccess:     true,
+		},
+		config: &Config{RequiredKeys: []string{"REQUIRED_PRESENT", "REQUIRED_MISSING"}},
+	}
+
+	err := c.LoadSecretToEnv(context.Background())
+	var missingErr MissingKeysError
+	assert.ErrorAs(t, err, &missingErr)
+	assert.Equal(t, []string{"REQUIRED_MISSING"}, missingErr.Keys)
+
+	_, set := os.LookupEnv("REQUIRED_PRESENT")
+	assert.False(t, set)
+}
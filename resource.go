@@ -0,0 +1,44 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrInvalidResourceName is returned when a secret version resource name
+// does not have the expected shape.
+var ErrInvalidResourceName = errors.New("invalid secret version resource name")
+
+// versionResourcePattern matches the resource name of a secret version, with
+// an optional location for regional secrets.
+var versionResourcePattern = regexp.MustCompile(`^projects/([^/]+)/(?:locations/[^/]+/)?secrets/([^/]+)/versions/[^/]+$`)
+
+// GetSecretByName retrieves the secret version identified by its full
+// resource name, such as "projects/P/secrets/S/versions/V", ignoring the
+// project, secret name and version of the configuration. The other options,
+// such as timeout, retries and checksum verification, still apply.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - fullName: The resource name of the secret version, optionally with a
+// "locations/L/" segment for regional secrets.
+//
+// Returns:
+// - A string containing the secret value.
+// - An error wrapping ErrInvalidResourceName if fullName is malformed.
+// - An error if the secret retrieval fails.
+func (c *Client) GetSecretByName(ctx context.Context, fullName string) (string, error) {
+	match := versionResourcePattern.FindStringSubmatch(fullName)
+	if match == nil {
+		return "", fmt.Errorf("%w: %q must look like projects/PROJECT/secrets/SECRET/versions/VERSION", ErrInvalidResourceName, fullName)
+	}
+
+	result, err := c.accessResource(ctx, match[2], fullName)
+	if err != nil {
+		return "", err
+	}
+
+	return trimBOM(string(result.Payload.Data)), nil
+}
@@ -0,0 +1,54 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSecretByName(t *testing.T) {
+	testCases := []struct {
+		name        string
+		fullName    string
+		expectedErr error
+	}{
+		{name: "global secret", fullName: "projects/other/secrets/db/versions/3"},
+		{name: "regional secret", fullName: "projects/other/locations/europe-west4/secrets/db/versions/latest"},
+		{name: "missing versions segment", fullName: "projects/other/secrets/db", expectedErr: ErrInvalidResourceName},
+		{name: "missing projects prefix", fullName: "secrets/db/versions/3", expectedErr: ErrInvalidResourceName},
+		{name: "short name", fullName: "db", expectedErr: ErrInvalidResourceName},
+		{name: "trailing segment", fullName: "projects/other/secrets/db/versions/3/extra", expectedErr: ErrInvalidResourceName},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := &nameRecorder{mockSecretManagerClient: mockSecretManagerClient{secretPayload: "value", isSuccess: true}}
+			c := &Client{
+				client: recorder,
+				config: &Config{ProjectID: "test-id", SecretName: "test-name", SecretVersion: "1"},
+			}
+
+			value, err := c.GetSecretByName(context.Background(), tc.fullName)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, recorder.names)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "value", value)
+			assert.Equal(t, []string{tc.fullName}, recorder.names)
+		})
+	}
+}
+
+func TestGetSecretByNameStripsBOM(t *testing.T) {
+	c := &Client{
+		client: &mockSecretManagerClient{secretPayload: "\ufeffvalue", isSuccess: true},
+		config: &Config{ProjectID: "test-id"},
+	}
+
+	value, err := c.GetSecretByName(context.Background(), "projects/other/secrets/db/versions/3")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
@@ -0,0 +1,57 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+)
+
+// LoadSecretToEnvWithRestore works like LoadSecretToEnv and also returns a
+// function reverting the environment to its state before the call. The
+// previous value of every variable set is captured with os.LookupEnv, so
+// restore sets back variables that held a value, even an empty one, and
+// unsets variables that did not exist.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - A restore function, never nil, that also reverts the variables set before
+// an error occurred. It is not safe to call concurrently with other changes
+// to the same variables.
+// - An error if the secret retrieval or environment variable setting fails.
+func (c *Client) LoadSecretToEnvWithRestore(ctx context.Context) (func(), error) {
+	type previousValue struct {
+		value  string
+		exists bool
+	}
+	previous := make(map[string]previousValue)
+
+	// Record the previous state of every variable just before it is set
+	cfg := *c.config
+	onSet := cfg.OnSet
+	cfg.OnSet = func(key, value string) error {
+		if onSet != nil {
+			if err := onSet(key, value); err != nil {
+				return err
+			}
+		}
+		if _, recorded := previous[key]; !recorded {
+			v, exists := os.LookupEnv(key)
+			previous[key] = previousValue{value: v, exists: exists}
+		}
+		return nil
+	}
+
+	restore := func() {
+		for key, prev := range previous {
+			if prev.exists {
+				os.Setenv(key, prev.value)
+			} else {
+				os.Unsetenv(key)
+			}
+		}
+	}
+
+	_, err := c.loadSecretToEnv(ctx, &cfg, nil)
+	return restore, err
+}
@@ -0,0 +1,51 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSecretToEnvWithRestore(t *testing.T) {
+	t.Setenv("RESTORE_EXISTING", "before")
+	t.Setenv("RESTORE_EMPTY", "")
+	t.Setenv("RESTORE_UNSET", "")
+	os.Unsetenv("RESTORE_UNSET")
+
+	c := &Client{
+		client: &mockSecretManagerClient{
+			secretPayload: "RESTORE_EXISTING=after\nRESTORE_EMPTY=filled\nRESTORE_UNSET=created\n",
+			isSuccess:     true,
+		},
+		config: &Config{},
+	}
+
+	restore, err := c.LoadSecretToEnvWithRestore(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "after", os.Getenv("RESTORE_EXISTING"))
+	assert.Equal(t, "filled", os.Getenv("RESTORE_EMPTY"))
+	assert.Equal(t, "created", os.Getenv("RESTORE_UNSET"))
+
+	restore()
+
+	assert.Equal(t, "before", os.Getenv("RESTORE_EXISTING"))
+	value, exists := os.LookupEnv("RESTORE_EMPTY")
+	assert.True(t, exists)
+	assert.Empty(t, value)
+	_, exists = os.LookupEnv("RESTORE_UNSET")
+	assert.False(t, exists)
+}
+
+func TestLoadSecretToEnvWithRestoreError(t *testing.T) {
+	c := &Client{
+		client: &mockSecretManagerClient{isSuccess: false},
+		config: &Config{},
+	}
+
+	restore, err := c.LoadSecretToEnvWithRestore(context.Background())
+	assert.Error(t, err)
+	assert.NotNil(t, restore)
+	restore()
+}
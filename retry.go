@@ -0,0 +1,91 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultRetryBaseDelay is the delay before the first retry when
+// RetryConfig.BaseDelay is not set.
+const defaultRetryBaseDelay = 100 * time.Millisecond
+
+// RetryConfig controls how secret access is retried when Secret Manager
+// returns a transient error. The zero value disables retries.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values of 0 or 1 disable retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. Each following retry
+	// doubles the previous delay. Defaults to 100ms when zero.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts. No cap is applied when zero.
+	MaxDelay time.Duration
+}
+
+// retryableCodes lists the gRPC status codes that are considered transient.
+var retryableCodes = map[codes.Code]bool{
+	codes.Unavailable:       true,
+	codes.Internal:          true,
+	codes.DeadlineExceeded:  true,
+	codes.ResourceExhausted: true,
+}
+
+// isRetryable reports whether err carries a transient gRPC status code.
+func isRetryable(err error) bool {
+	return retryableCodes[status.Code(err)]
+}
+
+// delay returns the backoff duration to wait before the given retry,
+// where retry starts at 1 for the first retry.
+func (r RetryConfig) delay(retry int) time.Duration {
+	d := r.BaseDelay
+	if d <= 0 {
+		d = defaultRetryBaseDelay
+	}
+	for i := 1; i < retry && (r.MaxDelay <= 0 || d < r.MaxDelay); i++ {
+		d *= 2
+	}
+	if r.MaxDelay > 0 && d > r.MaxDelay {
+		return r.MaxDelay
+	}
+	return d
+}
+
+// withRetry calls fn until it succeeds, returns a non-retryable error, or the
+// configured number of attempts is exhausted. The wait between attempts is
+// interrupted as soon as ctx is done.
+//
+// Parameters:
+// - ctx: The context used to stop retrying when cancelled.
+// - cfg: The retry configuration.
+// - fn: The operation to run.
+//
+// Returns:
+// - The last error returned by fn. If ctx is done while waiting, the context
+// error wrapped together with the last error returned by fn.
+func withRetry(ctx context.Context, cfg RetryConfig, fn func() error) error {
+	attempts := cfg.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil || !isRetryable(err) || attempt == attempts {
+			return err
+		}
+
+		timer := time.NewTimer(cfg.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+	return err
+}
@@ -0,0 +1,100 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetSecretRetry(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	notFound := status.Error(codes.NotFound, "not found")
+
+	testCases := []struct {
+		name          string
+		errs          []error
+		retry         RetryConfig
+		expectedCalls int
+		expectedCode  codes.Code
+	}{
+		{
+			name:          "success after transient errors",
+			errs:          []error{unavailable, status.Error(codes.DeadlineExceeded, "deadline")},
+			retry:         RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			expectedCalls: 3,
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "fail fast on non-retryable error",
+			errs:          []error{notFound},
+			retry:         RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			expectedCalls: 1,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name:          "fail after max attempts",
+			errs:          []error{unavailable, unavailable, unavailable},
+			retry:         RetryConfig{MaxAttempts: 2, BaseDelay: time.Millisecond},
+			expectedCalls: 2,
+			expectedCode:  codes.Unavailable,
+		},
+		{
+			name:          "no retry with zero config",
+			errs:          []error{unavailable},
+			expectedCalls: 1,
+			expectedCode:  codes.Unavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &mockSecretManagerClient{
+				secretPayload: "FOO=bar",
+				isSuccess:     true,
+				errs:          tc.errs,
+			}
+			c := &Client{client: mock, config: &Config{Retry: tc.retry}}
+
+			_, err := c.GetSecret(context.Background())
+			assert.Equal(t, tc.expectedCalls, mock.calls)
+			if tc.expectedCode == codes.OK {
+				assert.NoError(t, err)
+				return
+			}
+			st, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, tc.expectedCode, st.Code())
+		})
+	}
+}
+
+func TestGetSecretRetryCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mock := &mockSecretManagerClient{
+		errs: []error{status.Error(codes.Unavailable, "unavailable")},
+	}
+	c := &Client{client: mock, config: &Config{
+		Retry: RetryConfig{MaxAttempts: 5, BaseDelay: time.Hour},
+	}}
+
+	_, err := c.GetSecret(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Contains(t, err.Error(), "last error")
+	assert.Equal(t, 1, mock.calls)
+}
+
+func TestRetryConfigDelay(t *testing.T) {
+	r := RetryConfig{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	assert.Equal(t, 10*time.Millisecond, r.delay(1))
+	assert.Equal(t, 20*time.Millisecond, r.delay(2))
+	assert.Equal(t, 40*time.Millisecond, r.delay(3))
+	assert.Equal(t, 50*time.Millisecond, r.delay(4))
+	assert.Equal(t, defaultRetryBaseDelay, RetryConfig{}.delay(1))
+}
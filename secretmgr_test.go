@@ -2,13 +2,21 @@ package GCPSecretManager
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
+	"hash/crc32"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
 	"github.com/googleapis/gax-go/v2"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/api/option"
 )
@@ -16,20 +24,51 @@ import (
 type mockSecretManagerClient struct {
 	secretPayload string
 	isSuccess     bool
+	// errs is returned one entry per call before falling back to isSuccess
+	errs  []error
+	calls int
+	// crc32c is returned as the payload checksum when set
+	crc32c     *int64
+	closeCalls int
+	// version is returned by GetSecretVersion when set
+	version *secretmanagerpb.SecretVersion
 }
 
 func (m *mockSecretManagerClient) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	m.calls++
+	if m.calls <= len(m.errs) && m.errs[m.calls-1] != nil {
+		return nil, m.errs[m.calls-1]
+	}
 	if m.isSuccess {
 		return &secretmanagerpb.AccessSecretVersionResponse{
 			Payload: &secretmanagerpb.SecretPayload{
-				Data: []byte(m.secretPayload),
+				Data:       []byte(m.secretPayload),
+				DataCrc32C: m.crc32c,
 			},
 		}, nil
 	}
 	return nil, fmt.Errorf("access error")
 }
 
+func (m *mockSecretManagerClient) GetSecretVersion(ctx context.Context, req *secretmanagerpb.GetSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	m.calls++
+	if m.calls <= len(m.errs) && m.errs[m.calls-1] != nil {
+		return nil, m.errs[m.calls-1]
+	}
+	if m.version != nil {
+		return m.version, nil
+	}
+	if m.isSuccess {
+		return &secretmanagerpb.SecretVersion{
+			Name:  strings.Replace(req.Name, "/versions/latest", "/versions/1", 1),
+			State: secretmanagerpb.SecretVersion_ENABLED,
+		}, nil
+	}
+	return nil, fmt.Errorf("get version error")
+}
+
 func (m *mockSecretManagerClient) Close() error {
+	m.closeCalls++
 	return nil
 }
 
@@ -61,7 +100,7 @@ func TestNewSecret(t *testing.T) {
 				SecretVersion: "test-version",
 			},
 			runFn: func() {
-				defaultClientFactory = func(ctx context.Context, opts ...option.ClientOption) (secretManagerClient, error) {
+				defaultClientFactory = func(ctx context.Context, opts ...option.ClientOption) (SecretManagerClient, error) {
 					return &secretmanager.Client{}, nil
 				}
 			},
@@ -81,7 +120,7 @@ func TestNewSecret(t *testing.T) {
 				SecretName: "test-name",
 			},
 			runFn: func() {
-				defaultClientFactory = func(ctx context.Context, opts ...option.ClientOption) (secretManagerClient, error) {
+				defaultClientFactory = func(ctx context.Context, opts ...option.ClientOption) (SecretManagerClient, error) {
 					return nil, fmt.Errorf("error")
 				}
 			},
@@ -145,7 +184,7 @@ func TestLoadSecretToEnv(t *testing.T) {
 					secretPayload: "FOO=bar=baz",
 					isSuccess:     true,
 				},
-				config: &Config{},
+				config: &Config{RequireBrackets: true},
 			},
 			expectedErr: fmt.Errorf("failed to set environment variable"),
 		},
@@ -180,3 +219,1182 @@ func TestLoadSecretToEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestNewSecretWithConfig(t *testing.T) {
+	originDefaultClientFactory := defaultClientFactory
+	defer func() {
+		defaultClientFactory = originDefaultClientFactory
+	}()
+	defaultClientFactory = func(ctx context.Context, opts ...option.ClientOption) (SecretManagerClient, error) {
+		return &mockSecretManagerClient{}, nil
+	}
+
+	ctx := context.Background()
+
+	testCases := []struct {
+		name            string
+		cfg             *Config
+		expectedVersion string
+		expectedErr     error
+	}{
+		{
+			name: "success with explicit version",
+			cfg: &Config{
+				ProjectID:     "test-id",
+				SecretName:    "test-name",
+				SecretVersion: "3",
+			},
+			expectedVersion: "3",
+		},
+		{
+			name: "success with default version",
+			cfg: &Config{
+				ProjectID:  "test-id",
+				SecretName: "test-name",
+			},
+			expectedVersion: "latest",
+		},
+		{
+			name:        "fail with nil config",
+			cfg:         nil,
+			expectedErr: ConfigError{MissingField: "GCP_PROJECT_ID"},
+		},
+		{
+			name:        "fail to get SECRET_NAME",
+			cfg:         &Config{ProjectID: "test-id"},
+			expectedErr: ConfigError{MissingField: "SECRET_NAME"},
+		},
+		{
+			name: "success with required explicit version",
+			cfg: &Config{
+				ProjectID:              "test-id",
+				SecretName:             "test-name",
+				SecretVersion:          "5",
+				RequireExplicitVersion: true,
+			},
+			expectedVersion: "5",
+		},
+		{
+			name: "fail to get required SECRET_VERSION",
+			cfg: &Config{
+				ProjectID:              "test-id",
+				SecretName:             "test-name",
+				RequireExplicitVersion: true,
+			},
+			expectedErr: ConfigError{MissingField: "SECRET_VERSION"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := NewSecretWithConfig(ctx, tc.cfg)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedVersion, client.config.SecretVersion)
+			assert.NotSame(t, tc.cfg, client.config)
+		})
+	}
+}
+
+func TestGetSecretAsMap(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name           string
+		mockClient     *Client
+		expectedValues map[string]string
+		expectedErr    error
+	}{
+		{
+			name: "success parse with bracketed value",
+			mockClient: &Client{
+				client: &mockSecretManagerClient{
+					secretPayload: "FOO=bar\n\n BAZ = [a=b] \n",
+					isSuccess:     true,
+				},
+				config: &Config{},
+			},
+			expectedValues: map[string]string{
+				"FOO": "bar",
+				"BAZ": "a=b",
+			},
+		},
+		{
+			name: "fail to access gcp secret manager",
+			mockClient: &Client{
+				client: &mockSecretManagerClient{
+					isSuccess: false,
+				},
+				config: &Config{},
+			},
+			expectedErr: fmt.Errorf("failed to retrieve secret"),
+		},
+		{
+			name: "fail to parse secret",
+			mockClient: &Client{
+				client: &mockSecretManagerClient{
+					secretPayload: "=bar",
+					isSuccess:     true,
+				},
+				config: &Config{},
+			},
+			expectedErr: fmt.Errorf("failed to parse secret"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := tc.mockClient.GetSecretAsMap(ctx)
+			if tc.expectedErr != nil {
+				assert.Contains(t, err.Error(), tc.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValues, values)
+		})
+	}
+}
+
+type deadlineRecorder struct {
+	mockSecretManagerClient
+	deadline time.Time
+}
+
+func (m *deadlineRecorder) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	m.deadline, _ = ctx.Deadline()
+	return m.mockSecretManagerClient.AccessSecretVersion(ctx, req, opts...)
+}
+
+func TestGetSecretTimeout(t *testing.T) {
+	testCases := []struct {
+		name        string
+		timeout     time.Duration
+		ctxTimeout  time.Duration
+		expectedMax time.Duration
+	}{
+		{
+			name:        "default timeout",
+			expectedMax: defaultTimeout,
+		},
+		{
+			name:        "configured timeout",
+			timeout:     time.Second,
+			expectedMax: time.Second,
+		},
+		{
+			name:        "earlier context deadline wins",
+			timeout:     time.Minute,
+			ctxTimeout:  time.Second,
+			expectedMax: time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.ctxTimeout)
+				defer cancel()
+			}
+
+			mock := &deadlineRecorder{mockSecretManagerClient: mockSecretManagerClient{isSuccess: true}}
+			c := &Client{client: mock, config: &Config{Timeout: tc.timeout}}
+
+			start := time.Now()
+			_, err := c.GetSecret(ctx)
+			assert.NoError(t, err)
+			assert.WithinDuration(t, start.Add(tc.expectedMax), mock.deadline, 100*time.Millisecond)
+		})
+	}
+}
+
+func TestGetSecretContextDeadlineOnly(t *testing.T) {
+	testCases := []struct {
+		name       string
+		ctxTimeout time.Duration
+	}{
+		{name: "no deadline is not capped"},
+		{name: "longer deadline is kept", ctxTimeout: time.Hour},
+		{name: "shorter deadline is kept", ctxTimeout: time.Second},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			var expected time.Time
+			if tc.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.ctxTimeout)
+				defer cancel()
+				expected, _ = ctx.Deadline()
+			}
+
+			mock := &deadlineRecorder{mockSecretManagerClient: mockSecretManagerClient{isSuccess: true}}
+			c := &Client{client: mock, config: &Config{Timeout: time.Millisecond, ContextDeadlineOnly: true}}
+
+			_, err := c.GetSecret(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, mock.deadline)
+		})
+	}
+}
+
+func TestParseLineKeyValidation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		line        string
+		looseKeys   bool
+		expectedKey string
+		expectedErr bool
+	}{
+		{name: "valid key", line: "MY_KEY=value", expectedKey: "MY_KEY"},
+		{name: "valid key with leading underscore", line: "_key1=value", expectedKey: "_key1"},
+		{name: "key with space", line: "MY KEY=value", expectedErr: true},
+		{name: "key with leading digit", line: "1KEY=value", expectedErr: true},
+		{name: "key with dash", line: "MY-KEY=value", expectedErr: true},
+		{name: "loose key with dash", line: "MY-KEY=value", looseKeys: true, expectedKey: "MY-KEY"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{LooseKeys: tc.looseKeys}
+			entry, err := cfg.parseLine(tc.line, 1)
+			if tc.expectedErr {
+				var parseErr ParseError
+				assert.ErrorAs(t, err, &parseErr)
+				assert.Contains(t, parseErr.Reason, "invalid key")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedKey, entry.Key)
+		})
+	}
+}
+
+func TestSetEnvValuesSkipExisting(t *testing.T) {
+	testCases := []struct {
+		name          string
+		skipExisting  bool
+		expectedValue string
+	}{
+		{name: "overwrite existing by default", expectedValue: "secret"},
+		{name: "keep existing empty value", skipExisting: true, expectedValue: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("SKIP_EXISTING_SET", "")
+			t.Setenv("SKIP_EXISTING_UNSET", "")
+			os.Unsetenv("SKIP_EXISTING_UNSET")
+
+			cfg := &Config{SkipExisting: tc.skipExisting}
+			_, err := cfg.setEnvValues(map[string]string{
+				"SKIP_EXISTING_SET":   "secret",
+				"SKIP_EXISTING_UNSET": "new",
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, os.Getenv("SKIP_EXISTING_SET"))
+			assert.Equal(t, "new", os.Getenv("SKIP_EXISTING_UNSET"))
+		})
+	}
+}
+
+func TestGetSecretChecksum(t *testing.T) {
+	valid := int64(crc32.Checksum([]byte("FOO=bar"), crc32.MakeTable(crc32.Castagnoli)))
+	invalid := valid + 1
+
+	testCases := []struct {
+		name            string
+		crc32c          *int64
+		disableChecksum bool
+		expectedErr     error
+	}{
+		{name: "matching checksum", crc32c: &valid},
+		{name: "missing checksum", crc32c: nil},
+		{name: "mismatching checksum", crc32c: &invalid, expectedErr: ErrChecksumMismatch},
+		{name: "mismatching checksum with verification disabled", crc32c: &invalid, disableChecksum: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: &mockSecretManagerClient{
+					secretPayload: "FOO=bar",
+					isSuccess:     true,
+					crc32c:        tc.crc32c,
+				},
+				config: &Config{DisableChecksum: tc.disableChecksum},
+			}
+
+			secret, err := c.GetSecret(context.Background())
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "FOO=bar", secret)
+		})
+	}
+}
+
+func TestGetSecretBytes(t *testing.T) {
+	binary := string([]byte{0x00, 0xff, 0xfe, 0x80})
+	c := &Client{
+		client: &mockSecretManagerClient{
+			secretPayload: binary,
+			isSuccess:     true,
+		},
+		config: &Config{},
+	}
+
+	data, err := c.GetSecretBytes(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0xff, 0xfe, 0x80}, data)
+
+	c.client = &mockSecretManagerClient{isSuccess: false}
+	_, err = c.GetSecretBytes(context.Background())
+	assert.ErrorContains(t, err, "failed to access secret")
+}
+
+func TestWriteTo(t *testing.T) {
+	payload := string([]byte{0x00, 0xff, 0xfe, 0x80}) + "-----BEGIN CERTIFICATE-----\n"
+	c := &Client{
+		client: &mockSecretManagerClient{
+			secretPayload: payload,
+			isSuccess:     true,
+		},
+		config: &Config{},
+	}
+
+	var buf bytes.Buffer
+	n, err := c.WriteTo(context.Background(), &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(payload)), n)
+	assert.Equal(t, []byte(payload), buf.Bytes())
+
+	_, err = c.WriteTo(context.Background(), failingWriter{})
+	assert.ErrorContains(t, err, "failed to write secret: write error")
+
+	c.client = &mockSecretManagerClient{isSuccess: false}
+	n, err = c.WriteTo(context.Background(), &buf)
+	assert.ErrorContains(t, err, "failed to access secret")
+	assert.Zero(t, n)
+}
+
+func TestSetEnvValuesKeyPrefix(t *testing.T) {
+	t.Setenv("SERVICE_A_DB_PASSWORD", "")
+	t.Setenv("DB_PASSWORD", "unchanged")
+
+	cfg := &Config{KeyPrefix: "SERVICE_A_"}
+	_, err := cfg.setEnvValues(map[string]string{"DB_PASSWORD": "secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", os.Getenv("SERVICE_A_DB_PASSWORD"))
+	assert.Equal(t, "unchanged", os.Getenv("DB_PASSWORD"))
+}
+
+func TestCloseTwice(t *testing.T) {
+	mock := &mockSecretManagerClient{}
+	c := &Client{client: mock, config: &Config{}}
+
+	assert.NoError(t, c.Close())
+	assert.NoError(t, c.Close())
+	assert.Equal(t, 1, mock.closeCalls)
+}
+
+func TestParseSecretComments(t *testing.T) {
+	content := "# database settings\nDB_HOST=localhost\n   # indented comment\n\nDB_PORT=5432\n"
+
+	values, err := (&Config{}).parseSecret(content, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"DB_HOST": "localhost", "DB_PORT": "5432"}, values)
+}
+
+func TestParseSecretHashInValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   Config
+		content  string
+		expected map[string]string
+	}{
+		{name: "leading hash", content: "COLOR=#ff0000\n", expected: map[string]string{"COLOR": "#ff0000"}},
+		{name: "hash only", content: "EMPTY_ISH=#\n", expected: map[string]string{"EMPTY_ISH": "#"}},
+		{name: "hash after space", content: "COLOR= #ff0000\n", expected: map[string]string{"COLOR": "#ff0000"}},
+		{name: "hash inside value", content: "URL=http://host/#anchor # not a comment\n", expected: map[string]string{"URL": "http://host/#anchor # not a comment"}},
+		{name: "quoted hash", content: "COLOR=\"#ff0000\"\n", expected: map[string]string{"COLOR": "#ff0000"}},
+		{name: "untrimmed value", config: Config{DisableTrimValues: true}, content: "COLOR=#ff0000 \n", expected: map[string]string{"COLOR": "#ff0000 "}},
+		{name: "next to comments", content: "# colors\nCOLOR=#ff0000\n  #COLOR=#000000\n", expected: map[string]string{"COLOR": "#ff0000"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := tc.config.parseSecret(tc.content, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, values)
+		})
+	}
+}
+
+func TestByteOrderMark(t *testing.T) {
+	t.Setenv("BOM_FIRST", "")
+	t.Setenv("BOM_SECOND", "")
+
+	payload := "\uFEFFBOM_FIRST=1\nBOM_SECOND=2\n"
+	c := &Client{
+		client: &mockSecretManagerClient{secretPayload: payload, isSuccess: true},
+		config: &Config{},
+	}
+
+	assert.NoError(t, c.LoadSecretToEnv(context.Background()))
+	assert.Equal(t, "1", os.Getenv("BOM_FIRST"))
+	assert.Equal(t, "2", os.Getenv("BOM_SECOND"))
+
+	secret, err := c.GetSecret(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "BOM_FIRST=1\nBOM_SECOND=2\n", secret)
+
+	// The raw payload is left untouched
+	data, err := c.GetSecretBytes(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(payload), data)
+
+	// Only a leading byte order mark is removed
+	values, err := (&Config{}).parseSecret("A=1\nB=\uFEFF2\n", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"A": "1", "B": "\uFEFF2"}, values)
+}
+
+func TestConfigLogger(t *testing.T) {
+	t.Setenv("LOGGER_TEST_NAME", "")
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).Level(zerolog.DebugLevel)
+	cfg := &Config{Logger: &logger}
+
+	_, err := cfg.setEnvValues(map[string]string{"LOGGER_TEST_NAME": "value"})
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `"level":"debug"`)
+	assert.Contains(t, buf.String(), `"key":"LOGGER_TEST_NAME"`)
+	assert.NotContains(t, buf.String(), "value")
+	assert.Same(t, &log.Logger, (&Config{}).logger())
+}
+
+func TestConfigLogKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		patterns []string
+		key      string
+		expected string
+	}{
+		{name: "default redacts password", key: "DB_PASSWORD", expected: "***"},
+		{name: "default redacts lowercase token", key: "api_token", expected: "***"},
+		{name: "default keeps host", key: "DB_HOST", expected: "DB_HOST"},
+		{name: "custom pattern", patterns: []string{"host"}, key: "DB_HOST", expected: "***"},
+		{name: "custom pattern replaces defaults", patterns: []string{"host"}, key: "DB_PASSWORD", expected: "DB_PASSWORD"},
+		{name: "empty patterns disable redaction", patterns: []string{}, key: "DB_PASSWORD", expected: "DB_PASSWORD"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{RedactKeyPatterns: tc.patterns}
+			assert.Equal(t, tc.expected, cfg.logKey(tc.key))
+		})
+	}
+}
+
+func TestLoadSecretToEnvContinueOnError(t *testing.T) {
+	t.Setenv("AGGREGATE_FIRST", "")
+	t.Setenv("AGGREGATE_SECOND", "")
+
+	c := &Client{
+		client: &mockSecretManagerClient{
+			secretPayload: "AGGREGATE_FIRST=1\nbroken\n=missing\nAGGREGATE_SECOND=2\n1BAD=3\n",
+			isSuccess:     true,
+		},
+		config: &Config{ContinueOnError: true},
+	}
+
+	err := c.LoadSecretToEnv(context.Background())
+	assert.ErrorContains(t, err, "failed to set environment variable")
+	assert.ErrorContains(t, err, "line 2")
+	assert.ErrorContains(t, err, "line 3")
+	assert.ErrorContains(t, err, "line 5")
+
+	var parseErr ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, "1", os.Getenv("AGGREGATE_FIRST"))
+	assert.Equal(t, "2", os.Getenv("AGGREGATE_SECOND"))
+}
+
+func TestNewSecretClientOptions(t *testing.T) {
+	originDefaultClientFactory := defaultClientFactory
+	defer func() {
+		defaultClientFactory = originDefaultClientFactory
+	}()
+
+	var received []option.ClientOption
+	defaultClientFactory = func(ctx context.Context, opts ...option.ClientOption) (SecretManagerClient, error) {
+		received = opts
+		return &mockSecretManagerClient{}, nil
+	}
+
+	credentials := option.WithCredentialsFile("/path/to/external-account.json")
+	_, err := NewSecret(context.Background(), Config{
+		ProjectID:  "test-id",
+		SecretName: "test-name",
+	}, credentials)
+	assert.NoError(t, err)
+	assert.Equal(t, []option.ClientOption{credentials}, received)
+}
+
+func TestNewSecretUserAgent(t *testing.T) {
+	originDefaultClientFactory := defaultClientFactory
+	defer func() {
+		defaultClientFactory = originDefaultClientFactory
+	}()
+
+	var received []option.ClientOption
+	defaultClientFactory = func(ctx context.Context, opts ...option.ClientOption) (SecretManagerClient, error) {
+		received = opts
+		return &mockSecretManagerClient{}, nil
+	}
+
+	credentials := option.WithCredentialsFile("/path/to/external-account.json")
+	_, err := NewSecret(context.Background(), Config{
+		ProjectID:  "test-id",
+		SecretName: "test-name",
+		UserAgent:  "billing-service/1.2",
+	}, credentials)
+	assert.NoError(t, err)
+	assert.Equal(t, []option.ClientOption{option.WithUserAgent("billing-service/1.2"), credentials}, received)
+
+	// No user agent option without a user agent
+	_, err = NewSecret(context.Background(), Config{ProjectID: "test-id", SecretName: "test-name"})
+	assert.NoError(t, err)
+	assert.Empty(t, received)
+}
+
+func TestParseLineBase64(t *testing.T) {
+	testCases := []struct {
+		name          string
+		line          string
+		decodeBase64  bool
+		expectedValue string
+		expectedErr   bool
+	}{
+		{name: "decode padded value", line: "CERT=base64:bGluZTEKbGluZTI=", decodeBase64: true, expectedValue: "line1\nline2"},
+		{name: "decode bracketed value", line: "CERT=[base64:YQ==]", decodeBase64: true, expectedValue: "a"},
+		{name: "keep literal when disabled", line: "CERT=base64:YWJj", expectedValue: "base64:YWJj"},
+		{name: "fail with invalid data", line: "CERT=base64:not-base64!", decodeBase64: true, expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{DecodeBase64: tc.decodeBase64}
+			entry, err := cfg.parseLine(tc.line, 1)
+			if tc.expectedErr {
+				var parseErr ParseError
+				assert.ErrorAs(t, err, &parseErr)
+				assert.Contains(t, parseErr.Reason, "invalid base64 value")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, entry.Value)
+		})
+	}
+}
+
+func TestParseLineEqualsInValue(t *testing.T) {
+	testCases := []struct {
+		name            string
+		line            string
+		requireBrackets bool
+		expectedValue   string
+		expectedErr     bool
+	}{
+		{name: "unwrapped value", line: "TOKEN=abc=def", expectedValue: "abc=def"},
+		{name: "query string", line: "QUERY=a=1&b=2", expectedValue: "a=1&b=2"},
+		{name: "bracketed value", line: "TOKEN=[abc=def]", expectedValue: "abc=def"},
+		{name: "bracketed value with required brackets", line: "TOKEN=[abc=def]", requireBrackets: true, expectedValue: "abc=def"},
+		{name: "unwrapped value with required brackets", line: "TOKEN=abc=def", requireBrackets: true, expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{RequireBrackets: tc.requireBrackets}
+			entry, err := cfg.parseLine(tc.line, 1)
+			if tc.expectedErr {
+				var parseErr ParseError
+				assert.ErrorAs(t, err, &parseErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, entry.Value)
+		})
+	}
+}
+
+func TestParseLineQuotes(t *testing.T) {
+	testCases := []struct {
+		name          string
+		line          string
+		expectedValue string
+		expectedErr   bool
+	}{
+		{name: "double quotes keep whitespace", line: `GREETING="  hello  "`, expectedValue: "  hello  "},
+		{name: "single quotes keep whitespace", line: `GREETING='  hello  '`, expectedValue: "  hello  "},
+		{name: "double quotes with escapes", line: `MSG="line1\nline2\t\"quoted\" \\ \x"`, expectedValue: "line1\nline2\t\"quoted\" \\ \\x"},
+		{name: "single quotes keep escapes", line: `MSG='line1\n'`, expectedValue: `line1\n`},
+		{name: "quoted value with equal sign", line: `DSN="user=app password=x"`, expectedValue: "user=app password=x"},
+		{name: "empty quoted value", line: `EMPTY=""`, expectedValue: ""},
+		{name: "mismatched quotes are literal", line: `MIXED="value'`, expectedValue: `"value'`},
+		{name: "unterminated escape", line: `BAD="value\"`, expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entry, err := (&Config{}).parseLine(tc.line, 1)
+			if tc.expectedErr {
+				var parseErr ParseError
+				assert.ErrorAs(t, err, &parseErr)
+				assert.Contains(t, parseErr.Reason, "invalid quoted value")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, entry.Value)
+		})
+	}
+}
+
+func TestLoadSecretToEnvCount(t *testing.T) {
+	testCases := []struct {
+		name          string
+		payload       string
+		config        *Config
+		expectedCount int
+	}{
+		{name: "count set variables", payload: "COUNT_A=1\nCOUNT_B=2\n", config: &Config{}, expectedCount: 2},
+		{name: "comments only", payload: "# nothing here\n\n", config: &Config{}, expectedCount: 0},
+		{name: "filtered keys are not counted", payload: "COUNT_A=1\nCOUNT_B=2\n", config: &Config{KeyFilter: AllowKeys("COUNT_A")}, expectedCount: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("COUNT_A", "")
+			t.Setenv("COUNT_B", "")
+
+			c := &Client{
+				client: &mockSecretManagerClient{
+					secretPayload: tc.payload,
+					isSuccess:     true,
+				},
+				config: tc.config,
+			}
+
+			count, err := c.LoadSecretToEnvCount(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
+
+// blockingCloseClient blocks in Close until release is closed.
+type blockingCloseClient struct {
+	mockSecretManagerClient
+	release chan struct{}
+}
+
+func (m *blockingCloseClient) Close() error {
+	<-m.release
+	return nil
+}
+
+func TestCloseWithContext(t *testing.T) {
+	mock := &blockingCloseClient{release: make(chan struct{})}
+	defer close(mock.release)
+	c := &Client{client: mock, config: &Config{}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := c.CloseWithContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "did not close in time")
+	assert.NoError(t, c.CloseWithContext(context.Background()))
+
+	closing := &mockSecretManagerClient{}
+	c = &Client{client: closing, config: &Config{}}
+	assert.NoError(t, c.CloseWithContext(context.Background()))
+	assert.Equal(t, 1, closing.closeCalls)
+}
+
+func TestParseSecretDuplicateKeys(t *testing.T) {
+	content := "DB_HOST=a\nDB_PORT=1\nDB_HOST=b\n"
+
+	values, err := (&Config{}).parseSecret(content, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", values["DB_HOST"])
+
+	_, err = (&Config{RejectDuplicateKeys: true}).parseSecret(content, nil)
+	var parseErr ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 3, parseErr.LineNum)
+	assert.EqualError(t, err, "invalid format at line 3 (DB_HOST=b): duplicate key DB_HOST, first defined at line 1")
+
+	values, err = (&Config{RejectDuplicateKeys: true, ContinueOnError: true}).parseSecret(content+"DB_HOST=c\n", nil)
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Contains(t, err.Error(), "invalid format at line 4 (DB_HOST=c): duplicate key DB_HOST, first defined at line 1")
+	assert.Equal(t, map[string]string{"DB_HOST": "a", "DB_PORT": "1"}, values)
+}
+
+func TestGetSecretEmptyPayload(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  Config
+		payload string
+		wantErr bool
+	}{
+		{name: "empty payload accepted by default", payload: ""},
+		{name: "empty payload rejected", config: Config{RejectEmptySecret: true}, payload: "", wantErr: true},
+		{name: "non-empty payload with rejection", config: Config{RejectEmptySecret: true}, payload: "KEY=value"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: &mockSecretManagerClient{secretPayload: tc.payload, isSuccess: true},
+				config: &tc.config,
+			}
+
+			content, err := c.GetSecret(context.Background())
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrEmptySecret)
+				assert.ErrorIs(t, c.LoadSecretToEnv(context.Background()), ErrEmptySecret)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.payload, content)
+		})
+	}
+}
+
+func TestParseSecretTrimValues(t *testing.T) {
+	content := "  PASSWORD = secret  \nTOKEN=a  b\t\n"
+
+	values, err := (&Config{}).parseSecret(content, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"PASSWORD": "secret", "TOKEN": "a  b"}, values)
+
+	values, err = (&Config{DisableTrimValues: true}).parseSecret(content, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"PASSWORD": " secret  ", "TOKEN": "a  b\t"}, values)
+}
+
+func TestLoadSecretToEnvOnSet(t *testing.T) {
+	t.Setenv("SVC_HOOK_ACCEPTED", "")
+	t.Setenv("SVC_HOOK_REJECTED", "before")
+
+	errRejected := fmt.Errorf("value not allowed")
+	var seen []string
+	c := &Client{
+		client: &mockSecretManagerClient{secretPayload: "HOOK_ACCEPTED=ok\nHOOK_REJECTED=bad\n", isSuccess: true},
+		config: &Config{
+			KeyPrefix: "SVC_",
+			OnSet: func(key, value string) error {
+				// The variable is not set yet when the callback runs
+				assert.NotEqual(t, value, os.Getenv(key))
+				seen = append(seen, key+"="+value)
+				if value == "bad" {
+					return errRejected
+				}
+				return nil
+			},
+		},
+	}
+
+	err := c.LoadSecretToEnv(context.Background())
+	assert.ErrorIs(t, err, errRejected)
+	assert.ErrorContains(t, err, "environment variable SVC_HOOK_REJECTED rejected")
+	assert.Equal(t, "before", os.Getenv("SVC_HOOK_REJECTED"))
+	assert.Contains(t, seen, "SVC_HOOK_REJECTED=bad")
+}
+
+func TestParseSecretLineDelimiter(t *testing.T) {
+	testCases := []struct {
+		name      string
+		delimiter string
+		content   string
+		expected  map[string]string
+	}{
+		{name: "default newline", content: "A=1\nB=2\n", expected: map[string]string{"A": "1", "B": "2"}},
+		{name: "semicolon", delimiter: ";", content: "A=1; B=2 ;\n# comment;C=3", expected: map[string]string{"A": "1", "B": "2", "C": "3"}},
+		{name: "null byte", delimiter: "\x00", content: "A=1\x00B=x;y\x00", expected: map[string]string{"A": "1", "B": "x;y"}},
+		{name: "multi-character delimiter", delimiter: "||", content: "A=1||B=2|3", expected: map[string]string{"A": "1", "B": "2|3"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := (&Config{LineDelimiter: tc.delimiter}).parseSecret(tc.content, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, values)
+		})
+	}
+}
+
+func TestParseLineTransformations(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   Config
+		line     string
+		expected secretEntry
+	}{
+		{
+			name:     "plain value",
+			line:     "TOKEN=abc",
+			expected: secretEntry{Key: "TOKEN", Value: "abc", RawValue: "abc", LineNum: 1},
+		},
+		{
+			name:     "plain value containing '='",
+			line:     "TOKEN=abc=def",
+			expected: secretEntry{Key: "TOKEN", Value: "abc=def", RawValue: "abc=def", LineNum: 1},
+		},
+		{
+			name:     "bracketed value",
+			line:     "TOKEN=[abc=def]",
+			expected: secretEntry{Key: "TOKEN", Value: "abc=def", RawValue: "[abc=def]", LineNum: 1, Unwrapped: true},
+		},
+		{
+			name:     "quoted value",
+			line:     `TOKEN="[abc=def]"`,
+			expected: secretEntry{Key: "TOKEN", Value: "[abc=def]", RawValue: `"[abc=def]"`, LineNum: 1, Unquoted: true},
+		},
+		{
+			name:     "bracketed base64 value",
+			config:   Config{DecodeBase64: true},
+			line:     "CERT=[base64:YQ==]",
+			expected: secretEntry{Key: "CERT", Value: "a", RawValue: "[base64:YQ==]", LineNum: 1, Unwrapped: true, Decoded: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entry, err := tc.config.parseLine(tc.line, 1)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, entry)
+		})
+	}
+}
+
+func TestNewSecretCredentialsJSON(t *testing.T) {
+	originDefaultClientFactory := defaultClientFactory
+	defer func() {
+		defaultClientFactory = originDefaultClientFactory
+	}()
+
+	var received []option.ClientOption
+	var passed []byte
+	defaultClientFactory = func(ctx context.Context, opts ...option.ClientOption) (SecretManagerClient, error) {
+		received = opts
+		passed = reflect.ValueOf(opts[0]).Bytes()
+		assert.Equal(t, `{"type":"service_account"}`, string(passed))
+		return &mockSecretManagerClient{}, nil
+	}
+
+	credentials := []byte(`{"type":"service_account"}`)
+	endpoint := option.WithEndpoint("localhost:1234")
+	client, err := NewSecret(context.Background(), Config{
+		ProjectID:       "test-id",
+		SecretName:      "test-name",
+		CredentialsJSON: credentials,
+	}, endpoint)
+	assert.NoError(t, err)
+
+	assert.Len(t, received, 2)
+	assert.Equal(t, "option.withCredentialsJSON", fmt.Sprintf("%T", received[0]))
+	assert.Equal(t, endpoint, received[1])
+	// The copy passed to the factory is cleared, the caller's slice is not
+	assert.Equal(t, make([]byte, len(credentials)), passed)
+	assert.Equal(t, `{"type":"service_account"}`, string(credentials))
+	assert.Nil(t, client.config.CredentialsJSON)
+}
+
+func TestParseSecretCRLF(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config Config
+	}{
+		{name: "default"},
+		{name: "values not trimmed", config: Config{DisableTrimValues: true}},
+		{name: "explicit newline delimiter", config: Config{LineDelimiter: "\n", DisableTrimValues: true}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := tc.config.parseSecret("# comment\r\nDB_HOST=localhost\r\n\r\nDB_PASS=\"p\"\r\nLAST=end", nil)
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]string{"DB_HOST": "localhost", "DB_PASS": "p", "LAST": "end"}, values)
+		})
+	}
+}
+
+func TestEachSecretLine(t *testing.T) {
+	errStop := fmt.Errorf("stop")
+
+	testCases := []struct {
+		name        string
+		payload     string
+		config      Config
+		stopAt      string
+		expected    []string
+		expectedErr error
+		errContains string
+	}{
+		{
+			name:     "every line in order",
+			payload:  "# comment\nB=2\nA=1\nB=3\n",
+			expected: []string{"B=2", "A=1", "B=3"},
+		},
+		{
+			name:        "callback error stops iteration",
+			payload:     "A=1\nB=2\nC=3\n",
+			stopAt:      "B",
+			expected:    []string{"A=1", "B=2"},
+			expectedErr: errStop,
+		},
+		{
+			name:        "parse error stops iteration",
+			payload:     "A=1\nbroken\nC=3\n",
+			expected:    []string{"A=1"},
+			errContains: "failed to parse secret: invalid format at line 2",
+		},
+		{
+			name:        "parse errors collected",
+			payload:     "A=1\nbroken\nC=3\n",
+			config:      Config{ContinueOnError: true},
+			expected:    []string{"A=1", "C=3"},
+			errContains: "failed to parse secret: invalid format at line 2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: &mockSecretManagerClient{secretPayload: tc.payload, isSuccess: true},
+				config: &tc.config,
+			}
+
+			var seen []string
+			err := c.EachSecretLine(context.Background(), func(key, value string) error {
+				seen = append(seen, key+"="+value)
+				if key == tc.stopAt {
+					return errStop
+				}
+				return nil
+			})
+			assert.Equal(t, tc.expected, seen)
+			switch {
+			case tc.expectedErr != nil:
+				assert.ErrorIs(t, err, tc.expectedErr)
+			case tc.errContains != "":
+				assert.ErrorContains(t, err, tc.errContains)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetSecretLines(t *testing.T) {
+	testCases := []struct {
+		name     string
+		payload  string
+		config   Config
+		expected []string
+	}{
+		{
+			name:     "json lines",
+			payload:  "{\"name\":\"a\",\"v\":\"x=y\"}\r\n\n  # comment\n  {\"name\":\"b\"}  \n",
+			expected: []string{`{"name":"a","v":"x=y"}`, `{"name":"b"}`},
+		},
+		{
+			name:     "custom delimiter",
+			payload:  "one;two; ;three",
+			config:   Config{LineDelimiter: ";"},
+			expected: []string{"one", "two", "three"},
+		},
+		{
+			name:     "only comments",
+			payload:  "# nothing\n\n",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: &mockSecretManagerClient{secretPayload: tc.payload, isSuccess: true},
+				config: &tc.config,
+			}
+
+			lines, err := c.GetSecretLines(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, lines)
+		})
+	}
+
+	c := &Client{client: &mockSecretManagerClient{}, config: &Config{}}
+	_, err := c.GetSecretLines(context.Background())
+	assert.ErrorContains(t, err, "failed to retrieve secret")
+}
+
+func TestParseLineStrictValues(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      Config
+		line        string
+		expectedErr string
+	}{
+		{name: "plain value", config: Config{StrictValues: true}, line: "KEY=value"},
+		{name: "tab allowed", config: Config{StrictValues: true}, line: "KEY=\"a\\tb\""},
+		{name: "NUL byte accepted by default", line: "KEY=ab\x00c"},
+		{
+			name:        "NUL byte",
+			config:      Config{StrictValues: true},
+			line:        "KEY=ab\x00c",
+			expectedErr: "value contains control character U+0000 at byte 2",
+		},
+		{
+			name:        "escaped newline",
+			config:      Config{StrictValues: true},
+			line:        `KEY="a\nb"`,
+			expectedErr: "value contains control character U+000A at byte 1",
+		},
+		{
+			name:        "decoded base64 control character",
+			config:      Config{StrictValues: true, DecodeBase64: true},
+			line:        "KEY=base64:AQ==",
+			expectedErr: "value contains control character U+0001 at byte 0",
+		},
+		{
+			name:        "DEL character",
+			config:      Config{StrictValues: true},
+			line:        "KEY=a\x7f",
+			expectedErr: "value contains control character U+007F at byte 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.config.parseLine(tc.line, 1)
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var parseErr ParseError
+			assert.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tc.expectedErr, parseErr.Reason)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := (&Config{ContinueOnError: true}).parseSecret("A=1\nbroken\n=missing\nB=2\n", nil)
+
+	var parseErrs ParseErrors
+	assert.ErrorAs(t, err, &parseErrs)
+	assert.Len(t, parseErrs.Errors(), 2)
+	assert.Equal(t, 2, parseErrs.Errors()[0].LineNum)
+	assert.Equal(t, 3, parseErrs.Errors()[1].LineNum)
+	assert.True(t, strings.HasPrefix(err.Error(), "2 malformed lines: invalid format at line 2 (broken)"))
+
+	// Individual errors remain reachable through the wrapping chain
+	var parseErr ParseError
+	assert.ErrorAs(t, fmt.Errorf("failed to parse secret: %w", err), &parseErr)
+	assert.Equal(t, 2, parseErr.LineNum)
+
+	// A single collected error reads as the ParseError itself
+	var single ParseErrors
+	single.Add(ParseError{Line: "x", LineNum: 4, Reason: "missing '='"})
+	assert.EqualError(t, single, "invalid format at line 4 (x): missing '='")
+
+	// No error at all when every line is valid
+	_, err = (&Config{ContinueOnError: true}).parseSecret("A=1\n", nil)
+	assert.NoError(t, err)
+}
+
+func TestEachEntryErrors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		config    Config
+		content   string
+		keys      []string
+		stopped   bool
+		collected bool
+	}{
+		{name: "valid content", content: "A=1\nB=2\n", keys: []string{"A", "B"}},
+		{name: "malformed line stops parsing", content: "A=1\nbroken\nB=2\n", keys: []string{"A"}, stopped: true},
+		{name: "malformed lines collected", config: Config{ContinueOnError: true}, content: "A=1\nbroken\nB=2\n", keys: []string{"A", "B"}, collected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var keys []string
+			err := tc.config.eachEntry(tc.content, nil, func(entry secretEntry) error {
+				keys = append(keys, entry.Key)
+				return nil
+			})
+			assert.Equal(t, tc.keys, keys)
+			if tc.collected {
+				assert.True(t, isCollected(err))
+				return
+			}
+			assert.False(t, isCollected(err))
+			if !tc.stopped {
+				assert.NoError(t, err)
+				return
+			}
+			var parseErr ParseError
+			assert.ErrorAs(t, err, &parseErr)
+		})
+	}
+}
+
+func TestGetSecretVersion(t *testing.T) {
+	const prefix = "projects/p/secrets/s/versions/"
+	c := NewClient(&versionPayloadClient{payloads: map[string]string{
+		prefix + "1":      "old",
+		prefix + "2":      "new",
+		prefix + "latest": "new",
+	}}, &Config{ProjectID: "p", SecretName: "s", SecretVersion: "2"})
+
+	testCases := []struct {
+		name        string
+		version     string
+		expected    string
+		expectedErr error
+	}{
+		{name: "older version", version: "1", expected: "old"},
+		{name: "latest", version: LatestVersion, expected: "new"},
+		{name: "missing version", version: "3", expectedErr: ErrSecretNotFound},
+		{name: "invalid version", version: "1/../2", expectedErr: ErrInvalidVersion},
+		{name: "empty version", version: "", expectedErr: ErrInvalidVersion},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := c.GetSecretVersion(context.Background(), tc.version)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+
+			// The configured version is left untouched
+			assert.Equal(t, "2", c.config.SecretVersion)
+		})
+	}
+}
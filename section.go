@@ -0,0 +1,86 @@
+package GCPSecretManager
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrSectionNotFound is returned when the secret has no header for the
+// configured Section.
+var ErrSectionNotFound = errors.New("secret section not found")
+
+// sectionNamePattern matches the valid names of INI-style section headers.
+var sectionNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// sectionTracker follows the [section] headers of a secret and tells which
+// lines belong to the selected section.
+type sectionTracker struct {
+	selected string
+	current  string
+	// valid is false after a malformed or duplicate header, whose lines are
+	// skipped
+	valid bool
+	seen  map[string]int
+}
+
+// newSectionTracker returns a tracker selecting the given section. Every line
+// is kept when selected is empty.
+func newSectionTracker(selected string) *sectionTracker {
+	return &sectionTracker{selected: selected, valid: true, seen: make(map[string]int)}
+}
+
+// track inspects a line of the secret.
+//
+// Parameters:
+// - line: The line, trimmed at least on the left.
+// - lineNum: The line number, used for error reporting.
+//
+// Returns:
+// - true if the line is a header or does not belong to the selected section
+// or to the shared lines before the first header.
+// - A ParseError if the line is a malformed or duplicate header.
+func (t *sectionTracker) track(line string, lineNum int) (bool, error) {
+	if t.selected == "" {
+		return false, nil
+	}
+
+	header := strings.TrimSpace(line)
+	if !strings.HasPrefix(header, "[") {
+		return !t.valid || (t.current != "" && t.current != t.selected), nil
+	}
+
+	t.valid = false
+	name, ok := strings.CutPrefix(header, "[")
+	if name, ok = strings.CutSuffix(name, "]"); !ok || !sectionNamePattern.MatchString(name) {
+		return true, ParseError{
+			Line:    line,
+			LineNum: lineNum,
+			Reason:  fmt.Sprintf("invalid section header: must be [NAME] with NAME matching %s", sectionNamePattern),
+		}
+	}
+	if first, ok := t.seen[name]; ok {
+		return true, ParseError{
+			Line:    line,
+			LineNum: lineNum,
+			Reason:  fmt.Sprintf("duplicate section [%s], first defined at line %d", name, first),
+		}
+	}
+
+	t.seen[name] = lineNum
+	t.current, t.valid = name, true
+	return true, nil
+}
+
+// found returns an error wrapping ErrSectionNotFound if a section is selected
+// and the secret has no header for it.
+func (t *sectionTracker) found() error {
+	if t.selected == "" {
+		return nil
+	}
+	if _, ok := t.seen[t.selected]; !ok {
+		return fmt.Errorf("%w: [%s]", ErrSectionNotFound, t.selected)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package GCPSecretManager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSecretSection(t *testing.T) {
+	content := "SHARED=1\n[prod]\nDB_HOST=prod-db\n\n[staging]\nDB_HOST=staging-db\nDEBUG=true\n"
+
+	testCases := []struct {
+		name        string
+		config      Config
+		content     string
+		expected    map[string]string
+		expectedErr error
+		errContains string
+	}{
+		{
+			name:     "selected section with shared lines",
+			config:   Config{Section: "prod"},
+			content:  content,
+			expected: map[string]string{"SHARED": "1", "DB_HOST": "prod-db"},
+		},
+		{
+			name:     "other section",
+			config:   Config{Section: "staging"},
+			content:  content,
+			expected: map[string]string{"SHARED": "1", "DB_HOST": "staging-db", "DEBUG": "true"},
+		},
+		{
+			name:     "header with surrounding whitespace",
+			config:   Config{Section: "prod", DisableTrimValues: true},
+			content:  "  [prod]  \nDB_HOST=prod-db\n",
+			expected: map[string]string{"DB_HOST": "prod-db"},
+		},
+		{
+			name:        "headers rejected without section",
+			content:     content,
+			errContains: "invalid format at line 2 ([prod]): line must contain a '=' character",
+		},
+		{
+			name:        "missing section",
+			config:      Config{Section: "dev"},
+			content:     content,
+			expectedErr: ErrSectionNotFound,
+			errContains: "secret section not found: [dev]",
+		},
+		{
+			name:        "duplicate section",
+			config:      Config{Section: "prod"},
+			content:     "[prod]\nA=1\n[prod]\nB=2\n",
+			errContains: "invalid format at line 3 ([prod]): duplicate section [prod], first defined at line 1",
+		},
+		{
+			name:        "malformed header",
+			config:      Config{Section: "prod"},
+			content:     "[prod\nA=1\n",
+			errContains: "invalid format at line 1 ([prod): invalid section header",
+		},
+		{
+			name:        "empty header",
+			config:      Config{Section: "prod"},
+			content:     "[]\nA=1\n[prod]\n",
+			errContains: "invalid format at line 1 ([]): invalid section header",
+		},
+		{
+			name:        "lines after a malformed header are skipped",
+			config:      Config{Section: "prod", ContinueOnError: true},
+			content:     "[prod]\nA=1\n[prod staging]\nB=2\n",
+			expected:    map[string]string{"A": "1"},
+			errContains: "invalid format at line 3 ([prod staging]): invalid section header",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := tc.config.parseSecret(tc.content, nil)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+			if tc.errContains != "" {
+				assert.ErrorContains(t, err, tc.errContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tc.expected != nil {
+				assert.Equal(t, tc.expected, values)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package GCPSecretManager
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ExportAsShell retrieves the secret, parses it with the same rules and
+// transforms as LoadSecretToEnv, and writes the resulting variables to w as
+// POSIX shell export statements, one per line:
+//
+//	export KEY='VALUE'
+//
+// Values are single-quoted, so no character is interpreted by the shell.
+// Embedded single quotes end the quoted string, are escaped and reopen it, so
+// the value it's is written as:
+//
+//	'it'\''s'
+//
+// The output can therefore be evaluated with eval "$(...)". Keys must be
+// valid environment variable names, even when LooseKeys is set, since they
+// cannot be quoted.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - w: The writer receiving the script.
+//
+// Returns:
+// - An error if the secret retrieval or parsing fails, if a key is not a
+// valid environment variable name, or if writing to w fails. Nothing is
+// written when the secret cannot be retrieved or a key is invalid.
+func (c *Client) ExportAsShell(ctx context.Context, w io.Writer) error {
+	entries, err := c.getResolvedEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Validate every key before writing anything
+	for _, entry := range entries {
+		if !IsValidEnvKey(entry.Key) {
+			return fmt.Errorf("cannot export key %q: must match %s", entry.Key, EnvKeyPattern)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, entry := range entries {
+		fmt.Fprintf(bw, "export %s=%s\n", entry.Key, shellQuote(entry.Value))
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write shell script: %w", err)
+	}
+	return nil
+}
+
+// shellQuote returns value single-quoted for a POSIX shell, closing the
+// quotes around each embedded single quote.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
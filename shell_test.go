@@ -0,0 +1,113 @@
+package GCPSecretManager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, fmt.Errorf("write error")
+}
+
+func TestShellQuote(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "", expected: `''`},
+		{value: "plain", expected: `'plain'`},
+		{value: "with spaces", expected: `'with spaces'`},
+		{value: "it's", expected: `'it'\''s'`},
+		{value: `$HOME "double" $(rm -rf /) ` + "`id`", expected: `'$HOME "double" $(rm -rf /) ` + "`id`'"},
+		{value: "line1\nline2", expected: "'line1\nline2'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			assert.Equal(t, tc.expected, shellQuote(tc.value))
+		})
+	}
+}
+
+func TestExportAsShell(t *testing.T) {
+	testCases := []struct {
+		name        string
+		payload     string
+		config      Config
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "export with escaping",
+			payload:  "NAME=app\nQUOTE=\"it's\"\nSPACES=\"a b\"\nDOLLAR=$HOME\n",
+			config:   Config{KeyPrefix: "SVC_"},
+			expected: "export SVC_NAME='app'\nexport SVC_QUOTE='it'\\''s'\nexport SVC_SPACES='a b'\nexport SVC_DOLLAR='$HOME'\n",
+		},
+		{
+			name:        "invalid key with loose keys",
+			payload:     "GOOD=1\nbad-key=2\n",
+			config:      Config{LooseKeys: true},
+			expectedErr: `cannot export key "bad-key"`,
+		},
+		{
+			name:        "parse error",
+			payload:     "broken\n",
+			expectedErr: "failed to parse secret",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: &mockSecretManagerClient{secretPayload: tc.payload, isSuccess: true},
+				config: &tc.config,
+			}
+
+			var buf bytes.Buffer
+			err := c.ExportAsShell(context.Background(), &buf)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestExportAsShellWriteError(t *testing.T) {
+	c := &Client{
+		client: &mockSecretManagerClient{secretPayload: "KEY=value\n", isSuccess: true},
+		config: &Config{},
+	}
+
+	assert.ErrorContains(t, c.ExportAsShell(context.Background(), failingWriter{}), "failed to write shell script")
+}
+
+func TestExportAsShellEval(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+
+	value := `it's "quoted" $HOME $(echo injected) ` + "`id`" + ` \n`
+	c := &Client{
+		client: &mockSecretManagerClient{secretPayload: "EXPORTED=" + `"` + dotenvEscaper.Replace(value) + `"`, isSuccess: true},
+		config: &Config{},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, c.ExportAsShell(context.Background(), &buf))
+
+	out, err := exec.Command(sh, "-c", buf.String()+`printf '%s' "$EXPORTED"`).Output()
+	assert.NoError(t, err)
+	assert.Equal(t, value, string(out))
+}
@@ -0,0 +1,82 @@
+package GCPSecretManager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// redacted replaces sensitive values in formatted output.
+const redacted = "[REDACTED]"
+
+// String formats the identifying settings of the configuration, masking the
+// credentials, so that a Config can be logged safely with %v or %s. Hooks
+// and other settings without an identifying role are omitted.
+func (c Config) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Config{ProjectID: %q, SecretName: %q", c.ProjectID, c.SecretName)
+	if len(c.SecretNames) > 0 {
+		fmt.Fprintf(&b, ", SecretNames: %q", c.SecretNames)
+	}
+	fmt.Fprintf(&b, ", SecretVersion: %q", c.SecretVersion)
+	if c.Location != "" {
+		fmt.Fprintf(&b, ", Location: %q", c.Location)
+	}
+	if c.LocalFile != "" {
+		fmt.Fprintf(&b, ", LocalFile: %q", c.LocalFile)
+	}
+	if len(c.CredentialsJSON) > 0 {
+		fmt.Fprintf(&b, ", CredentialsJSON: %s", redacted)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// GoString formats the configuration like String, so %#v does not bypass
+// the redaction.
+func (c Config) GoString() string {
+	return c.String()
+}
+
+// MarshalZerologObject writes the identifying settings of the configuration
+// to a zerolog event, masking the credentials, so that logging a Config with
+// Interface or Object never leaks them.
+func (c Config) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("project_id", c.ProjectID).Str("secret_name", c.SecretName)
+	if len(c.SecretNames) > 0 {
+		e.Strs("secret_names", c.SecretNames)
+	}
+	e.Str("secret_version", c.SecretVersion)
+	if c.Location != "" {
+		e.Str("location", c.Location)
+	}
+	if c.LocalFile != "" {
+		e.Str("local_file", c.LocalFile)
+	}
+	if len(c.CredentialsJSON) > 0 {
+		e.Str("credentials_json", redacted)
+	}
+}
+
+// String formats the configuration of the client, as Config.String does. The
+// cached secret value is never included.
+func (c *Client) String() string {
+	if c == nil || c.config == nil {
+		return "Client{}"
+	}
+	return "Client{" + c.config.String() + "}"
+}
+
+// GoString formats the client like String.
+func (c *Client) GoString() string {
+	return c.String()
+}
+
+// MarshalZerologObject writes the configuration of the client to a zerolog
+// event, as Config.MarshalZerologObject does.
+func (c *Client) MarshalZerologObject(e *zerolog.Event) {
+	if c != nil && c.config != nil {
+		c.config.MarshalZerologObject(e)
+	}
+}
@@ -0,0 +1,104 @@
+package GCPSecretManager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ErrUnresolvedTemplate is returned when a template references a key that is
+// neither in the secret nor, when allowed, in the environment.
+var ErrUnresolvedTemplate = errors.New("unresolved template reference")
+
+// ErrTemplateCycle is returned when templates reference each other in a loop.
+var ErrTemplateCycle = errors.New("template reference cycle")
+
+// expandTemplates replaces the ${KEY} and $KEY references of every value with
+// the expanded value of KEY when ExpandTemplates is set. References are
+// expanded in dependency order, so a value may reference another template.
+// Keys missing from values are looked up in the environment when
+// ExpandFromEnv is set.
+//
+// Parameters:
+// - values: The parsed values, updated in place.
+//
+// Returns:
+// - An error wrapping ErrUnresolvedTemplate or ErrTemplateCycle, naming the
+// keys involved but never the values. values is left untouched on error.
+func (c *Config) expandTemplates(values map[string]string) error {
+	if !c.ExpandTemplates {
+		return nil
+	}
+
+	e := &templateExpander{
+		values:   values,
+		fromEnv:  c.ExpandFromEnv,
+		expanded: make(map[string]string, len(values)),
+		visiting: make(map[string]bool),
+	}
+
+	// Expand in key order so errors are deterministic
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, err := e.expand(key, []string{key}); err != nil {
+			return err
+		}
+	}
+
+	for key, value := range e.expanded {
+		values[key] = value
+	}
+	return nil
+}
+
+// templateExpander holds the state of a single expandTemplates call.
+type templateExpander struct {
+	values   map[string]string
+	fromEnv  bool
+	expanded map[string]string
+	visiting map[string]bool
+}
+
+// expand returns the expanded value of key, where chain lists the keys being
+// expanded, from the outermost one to key.
+func (e *templateExpander) expand(key string, chain []string) (string, error) {
+	if value, ok := e.expanded[key]; ok {
+		return value, nil
+	}
+	if e.visiting[key] {
+		return "", fmt.Errorf("%w: %s", ErrTemplateCycle, strings.Join(chain, " -> "))
+	}
+	e.visiting[key] = true
+	defer delete(e.visiting, key)
+
+	var err error
+	value := os.Expand(e.values[key], func(name string) string {
+		if err != nil {
+			return ""
+		}
+		if _, ok := e.values[name]; ok {
+			var value string
+			value, err = e.expand(name, append(chain, name))
+			return value
+		}
+		if e.fromEnv {
+			if value, ok := os.LookupEnv(name); ok {
+				return value
+			}
+		}
+		err = fmt.Errorf("key %s: %w: ${%s}", key, ErrUnresolvedTemplate, name)
+		return ""
+	})
+	if err != nil {
+		return "", err
+	}
+
+	e.expanded[key] = value
+	return value, nil
+}
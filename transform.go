@@ -0,0 +1,30 @@
+package GCPSecretManager
+
+import "strings"
+
+// keyUnderscoreReplacer replaces the separators commonly found in
+// human-friendly keys with underscores.
+var keyUnderscoreReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+// KeyToUpper is a key transform returning the key in upper case.
+func KeyToUpper(key string) string {
+	return strings.ToUpper(key)
+}
+
+// KeyToUnderscore is a key transform replacing dots and dashes with
+// underscores, so "db.password" becomes "db_password".
+func KeyToUnderscore(key string) string {
+	return keyUnderscoreReplacer.Replace(key)
+}
+
+// ChainKeyTransforms returns a key transform applying each transform in
+// order. For example ChainKeyTransforms(KeyToUnderscore, KeyToUpper) turns
+// "db.password" into "DB_PASSWORD".
+func ChainKeyTransforms(transforms ...func(string) string) func(string) string {
+	return func(key string) string {
+		for _, transform := range transforms {
+			key = transform(key)
+		}
+		return key
+	}
+}
@@ -0,0 +1,58 @@
+package GCPSecretManager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyTransforms(t *testing.T) {
+	assert.Equal(t, "DB.PASSWORD", KeyToUpper("db.password"))
+	assert.Equal(t, "db_password_v2", KeyToUnderscore("db.password-v2"))
+	assert.Equal(t, "DB_PASSWORD", ChainKeyTransforms(KeyToUnderscore, KeyToUpper)("db.password"))
+	assert.Equal(t, "db", ChainKeyTransforms()("db"))
+}
+
+func TestParseLineKeyTransform(t *testing.T) {
+	testCases := []struct {
+		name        string
+		transform   func(string) string
+		line        string
+		expectedKey string
+		expectedErr bool
+	}{
+		{name: "no transform", line: "db_host=x", expectedKey: "db_host"},
+		{name: "transform before validation", transform: ChainKeyTransforms(KeyToUnderscore, KeyToUpper), line: "db.password=x", expectedKey: "DB_PASSWORD"},
+		{name: "invalid key without transform", line: "db.password=x", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{KeyTransform: tc.transform}
+			entry, err := cfg.parseLine(tc.line, 1)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedKey, entry.Key)
+		})
+	}
+}
+
+func TestParseSecretNormalizeKeysUpper(t *testing.T) {
+	content := "db_password=a\nDb_User=b\n"
+
+	values, err := (&Config{NormalizeKeysUpper: true}).parseSecret(content, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"DB_PASSWORD": "a", "DB_USER": "b"}, values)
+
+	// Normalized keys collide before duplicate detection
+	_, err = (&Config{NormalizeKeysUpper: true, RejectDuplicateKeys: true}).parseSecret("db_x=1\nDB_X=2\n", nil)
+	assert.EqualError(t, err, "invalid format at line 2 (DB_X=2): duplicate key DB_X, first defined at line 1")
+
+	// Normalization runs after KeyTransform and before validation
+	values, err = (&Config{NormalizeKeysUpper: true, KeyTransform: KeyToUnderscore}).parseSecret("db.host=h\n", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"DB_HOST": "h"}, values)
+}
@@ -0,0 +1,126 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// secretTag is the struct tag holding the secret key of a field.
+const secretTag = "secret"
+
+// ErrInvalidTarget is returned by UnmarshalSecret when out is not a non-nil
+// pointer to a struct.
+var ErrInvalidTarget = errors.New("unmarshal target must be a non-nil pointer to a struct")
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// UnmarshalSecret retrieves the secret, parses its KEY=VALUE lines and
+// populates the fields of out tagged with `secret:"KEY"`. Untagged struct
+// fields are walked recursively, so nested configuration structs are filled
+// as well. Keys missing from the secret leave the field unchanged.
+//
+// Supported field types are string, bool, signed and unsigned integers,
+// floats and time.Duration, which is parsed with time.ParseDuration.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - out: A pointer to the struct to populate.
+//
+// Returns:
+// - ErrInvalidTarget if out is not a pointer to a struct.
+// - An error if the secret retrieval or parsing fails, or a value cannot be
+// converted to its field type.
+func (c *Client) UnmarshalSecret(ctx context.Context, out interface{}) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+
+	values, err := c.GetSecretAsMap(ctx)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalValues(values, target.Elem())
+}
+
+// unmarshalValues sets the tagged fields of the struct v from values.
+func unmarshalValues(values map[string]string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key, tagged := field.Tag.Lookup(secretTag)
+		if !tagged {
+			// Walk nested structs that are not themselves mapped to a key
+			if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+				if err := unmarshalValues(values, v.Field(i)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		raw, ok := values[key]
+		if !ok {
+			continue
+		}
+		if err := setField(v.Field(i), raw); err != nil {
+			return fmt.Errorf("failed to set field %s from key %s: %w", field.Name, key, err)
+		}
+	}
+
+	return nil
+}
+
+// setField converts raw to the type of field and assigns it.
+func setField(field reflect.Value, raw string) error {
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to duration: %w", raw, err)
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to bool: %w", raw, err)
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to %s: %w", raw, field.Type(), err)
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to %s: %w", raw, field.Type(), err)
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot convert %q to %s: %w", raw, field.Type(), err)
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
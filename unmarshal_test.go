@@ -0,0 +1,126 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type databaseConfig struct {
+	Host     string `secret:"DB_HOST"`
+	Port     int    `secret:"DB_PORT"`
+	Password string `secret:"DB_PASSWORD"`
+}
+
+type appConfig struct {
+	Name     string        `secret:"APP_NAME"`
+	Debug    bool          `secret:"APP_DEBUG"`
+	Timeout  time.Duration `secret:"APP_TIMEOUT"`
+	Ratio    float64       `secret:"APP_RATIO"`
+	Workers  uint8         `secret:"APP_WORKERS"`
+	Missing  string        `secret:"APP_MISSING"`
+	Database databaseConfig
+	ignored  string
+}
+
+func TestUnmarshalSecret(t *testing.T) {
+	ctx := context.Background()
+	payload := "APP_NAME=svc\nAPP_DEBUG=true\nAPP_TIMEOUT=1m30s\nAPP_RATIO=0.5\nAPP_WORKERS=4\n" +
+		"DB_HOST=localhost\nDB_PORT=5432\nDB_PASSWORD=[p=ss]\n"
+
+	testCases := []struct {
+		name        string
+		payload     string
+		out         interface{}
+		expected    interface{}
+		expectedErr string
+	}{
+		{
+			name:    "success with nested struct",
+			payload: payload,
+			out:     &appConfig{Missing: "default"},
+			expected: &appConfig{
+				Name:    "svc",
+				Debug:   true,
+				Timeout: 90 * time.Second,
+				Ratio:   0.5,
+				Workers: 4,
+				Missing: "default",
+				Database: databaseConfig{
+					Host:     "localhost",
+					Port:     5432,
+					Password: "p=ss",
+				},
+			},
+		},
+		{
+			name:        "fail with type mismatch",
+			payload:     "DB_PORT=abc",
+			out:         &databaseConfig{},
+			expectedErr: "failed to set field Port from key DB_PORT",
+		},
+		{
+			name:        "fail with overflow",
+			payload:     "APP_WORKERS=300",
+			out:         &appConfig{},
+			expectedErr: "cannot convert \"300\" to uint8",
+		},
+		{
+			name:        "fail with non-pointer target",
+			payload:     payload,
+			out:         appConfig{},
+			expectedErr: ErrInvalidTarget.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: &mockSecretManagerClient{
+					secretPayload: tc.payload,
+					isSuccess:     true,
+				},
+				config: &Config{},
+			}
+
+			err := c.UnmarshalSecret(ctx, tc.out)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, tc.out)
+		})
+	}
+}
+
+func TestGetSecretAsMapPipeline(t *testing.T) {
+	c := NewClient(&versionPayloadClient{payloads: map[string]string{
+		"projects/p/secrets/main/versions/latest":      "DB_HOST=db\nDB_PASSWORD=ref:projects/p/secrets/shared/versions/1\nAPP_NAME=${DB_HOST}-svc\n",
+		"projects/p/secrets/shared/versions/1":         "shared-password",
+		"projects/p/secrets/overrides/versions/latest": "DB_PORT=5432\n",
+	}}, &Config{
+		ProjectID:         "p",
+		SecretName:        "main,overrides",
+		SecretVersion:     LatestVersion,
+		ResolveReferences: true,
+		ExpandTemplates:   true,
+	})
+
+	values, err := c.GetSecretAsMap(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"DB_HOST":     "db",
+		"DB_PASSWORD": "shared-password",
+		"DB_PORT":     "5432",
+		"APP_NAME":    "db-svc",
+	}, values)
+
+	var out appConfig
+	assert.NoError(t, c.UnmarshalSecret(context.Background(), &out))
+	assert.Equal(t, "db-svc", out.Name)
+	assert.Equal(t, "shared-password", out.Database.Password)
+	assert.Equal(t, 5432, out.Database.Port)
+}
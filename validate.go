@@ -0,0 +1,66 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ValidateSecret retrieves the secret and runs the full KEY=VALUE parsing
+// without modifying the process environment. It is meant as a pre-flight
+// check, for example in CI before rolling out a new secret version. Every
+// secret of a comma-separated SecretName is checked, and references and
+// templates are resolved as LoadSecretToEnv does, so a dangling reference
+// or template is reported too.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - The environment variable names that LoadSecretToEnv would set, honoring
+// KeyFilter and including KeyPrefix and the PrefixWithSecretName namespace,
+// in the order they first appear in the secret. When
+// ContinueOnError is set, the keys of valid lines are returned even if
+// malformed lines were found.
+// - An error if the secret retrieval fails, a line is malformed, or a
+// reference or template cannot be resolved.
+func (c *Client) ValidateSecret(ctx context.Context) ([]string, error) {
+	entries, err := c.getSecretEntries(ctx, c.config, nil)
+	if err != nil {
+		var parseErr ParseError
+		if errors.As(err, &parseErr) {
+			err = fmt.Errorf("failed to parse secret: %w", err)
+		}
+		if entries == nil {
+			return nil, err
+		}
+	}
+
+	resolved := c.config.resolveEntries(entries)
+	keys := make([]string, 0, len(resolved))
+	for _, entry := range resolved {
+		keys = append(keys, entry.Key)
+	}
+
+	return keys, err
+}
+
+// ListKeys retrieves and parses the secret and returns the environment
+// variable names it would set, sorted, without ever exposing the values. It
+// suits key inventories in audits and CI checks. Parsing follows the same
+// rules as ValidateSecret.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - The sorted environment variable names, honoring KeyFilter and including
+// KeyPrefix. When ContinueOnError is set, the keys of valid lines are
+// returned even if malformed lines were found.
+// - An error if the secret retrieval fails or a line is malformed.
+func (c *Client) ListKeys(ctx context.Context) ([]string, error) {
+	keys, err := c.ValidateSecret(ctx)
+	sort.Strings(keys)
+	return keys, err
+}
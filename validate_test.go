@@ -0,0 +1,192 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSecret(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name         string
+		payload      string
+		isSuccess    bool
+		config       *Config
+		expectedKeys []string
+		expectedErr  error
+	}{
+		{
+			name:         "success returns keys in order",
+			payload:      "VALIDATE_B=1\n# comment\nVALIDATE_A=2\nVALIDATE_B=3\n",
+			isSuccess:    true,
+			config:       &Config{},
+			expectedKeys: []string{"VALIDATE_B", "VALIDATE_A"},
+		},
+		{
+			name:         "success with key prefix",
+			payload:      "VALIDATE_A=1",
+			isSuccess:    true,
+			config:       &Config{KeyPrefix: "SVC_"},
+			expectedKeys: []string{"SVC_VALIDATE_A"},
+		},
+		{
+			name:        "fail with malformed line",
+			payload:     "VALIDATE_A=1\nbroken",
+			isSuccess:   true,
+			config:      &Config{},
+			expectedErr: fmt.Errorf("failed to parse secret"),
+		},
+		{
+			name:         "fail with malformed line and continue on error",
+			payload:      "VALIDATE_A=1\nbroken",
+			isSuccess:    true,
+			config:       &Config{ContinueOnError: true},
+			expectedKeys: []string{"VALIDATE_A"},
+			expectedErr:  fmt.Errorf("line 2"),
+		},
+		{
+			name:        "fail to access gcp secret manager",
+			config:      &Config{},
+			expectedErr: fmt.Errorf("failed to retrieve secret"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: &mockSecretManagerClient{
+					secretPayload: tc.payload,
+					isSuccess:     tc.isSuccess,
+				},
+				config: tc.config,
+			}
+
+			keys, err := c.ValidateSecret(ctx)
+			if tc.expectedErr != nil {
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedKeys, keys)
+			_, set := os.LookupEnv("VALIDATE_A")
+			assert.False(t, set)
+		})
+	}
+}
+
+func TestListKeys(t *testing.T) {
+	testCases := []struct {
+		name         string
+		payload      string
+		isSuccess    bool
+		config       *Config
+		expectedKeys []string
+		errContains  string
+	}{
+		{
+			name:         "sorted and deduplicated",
+			payload:      "ZETA=secret-z\n# comment\nALPHA=secret-a\nZETA=secret-z2\nMIDDLE=secret-m\n",
+			isSuccess:    true,
+			config:       &Config{},
+			expectedKeys: []string{"ALPHA", "MIDDLE", "ZETA"},
+		},
+		{
+			name:         "filter, prefix and namespace applied",
+			payload:      "B=1\nA=2\nC=3\n",
+			isSuccess:    true,
+			config:       &Config{SecretName: "billing-db", KeyFilter: DenyKeys("BILLING_DB_C"), KeyPrefix: "SVC_", PrefixWithSecretName: true},
+			expectedKeys: []string{"SVC_BILLING_DB_A", "SVC_BILLING_DB_B"},
+		},
+		{
+			name:         "malformed line with continue on error",
+			payload:      "B=1\nbroken\nA=2\n",
+			isSuccess:    true,
+			config:       &Config{ContinueOnError: true},
+			expectedKeys: []string{"A", "B"},
+			errContains:  "line 2",
+		},
+		{
+			name:        "malformed line",
+			payload:     "B=1\nbroken\n",
+			isSuccess:   true,
+			config:      &Config{},
+			errContains: "failed to parse secret",
+		},
+		{
+			name:        "retrieval failure",
+			config:      &Config{},
+			errContains: "failed to retrieve secret",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: &mockSecretManagerClient{secretPayload: tc.payload, isSuccess: tc.isSuccess},
+				config: tc.config,
+			}
+
+			keys, err := c.ListKeys(context.Background())
+			if tc.errContains != "" {
+				assert.ErrorContains(t, err, tc.errContains)
+				assert.NotContains(t, err.Error(), "secret-")
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedKeys, keys)
+		})
+	}
+}
+
+func TestMultipleSecretNames(t *testing.T) {
+	payloads := map[string]string{
+		"base":     "HOST=base\nUSER=app\n",
+		"override": "HOST=override\nURL=${USER}@${HOST}\n",
+	}
+	newClient := func(cfg Config) *Client {
+		cfg.ProjectID = "p"
+		cfg.SecretName = "base,override"
+		return NewClient(&namedMockClient{payloads: payloads}, &cfg)
+	}
+
+	t.Run("validate", func(t *testing.T) {
+		keys, err := newClient(Config{ExpandTemplates: true}).ValidateSecret(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"HOST", "USER", "URL"}, keys)
+	})
+
+	t.Run("list keys", func(t *testing.T) {
+		keys, err := newClient(Config{PrefixWithSecretName: true}).ListKeys(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"BASE_HOST", "BASE_USER", "OVERRIDE_HOST", "OVERRIDE_URL"}, keys)
+	})
+
+	t.Run("write file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".env")
+		assert.NoError(t, newClient(Config{ExpandTemplates: true}).WriteSecretToFile(context.Background(), path, 0o600))
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "HOST=override\nUSER=app\nURL=app@override\n", string(data))
+	})
+
+	t.Run("export as shell", func(t *testing.T) {
+		var out strings.Builder
+		assert.NoError(t, newClient(Config{PrefixWithSecretName: true}).ExportAsShell(context.Background(), &out))
+		assert.Contains(t, out.String(), "export OVERRIDE_HOST='override'\n")
+		assert.Contains(t, out.String(), "export BASE_USER='app'\n")
+	})
+
+	t.Run("each secret line", func(t *testing.T) {
+		err := newClient(Config{}).EachSecretLine(context.Background(), func(key, value string) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrMultipleSecrets)
+	})
+}
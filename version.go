@@ -0,0 +1,167 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+)
+
+// LatestVersion is the version identifier that resolves to the most recent
+// enabled version of a secret.
+const LatestVersion = "latest"
+
+// ErrInvalidVersion is returned when a secret version cannot be used to build
+// a valid resource path.
+var ErrInvalidVersion = errors.New("invalid secret version")
+
+// VersionKind describes how a secret version is referenced.
+type VersionKind int
+
+const (
+	// VersionLatest refers to the "latest" version.
+	VersionLatest VersionKind = iota
+	// VersionNumber refers to a concrete numeric version such as "7".
+	VersionNumber
+	// VersionAlias refers to a version alias such as "prod".
+	VersionAlias
+)
+
+// String returns a readable name for the version kind.
+func (k VersionKind) String() string {
+	switch k {
+	case VersionLatest:
+		return "latest"
+	case VersionNumber:
+		return "number"
+	case VersionAlias:
+		return "alias"
+	default:
+		return fmt.Sprintf("VersionKind(%d)", int(k))
+	}
+}
+
+var (
+	versionNumberPattern = regexp.MustCompile(`^[0-9]+$`)
+	versionAliasPattern  = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// KindOfVersion reports whether version is "latest", a numeric version or an
+// alias. An empty version is treated as "latest", matching the default
+// applied by NewSecretWithConfig.
+func KindOfVersion(version string) VersionKind {
+	switch {
+	case version == "" || version == LatestVersion:
+		return VersionLatest
+	case versionNumberPattern.MatchString(version):
+		return VersionNumber
+	default:
+		return VersionAlias
+	}
+}
+
+// WithVersionAlias returns a copy of the Config that reads the secret through
+// the given version alias, for example one set by rotation tooling. Unlike
+// leaving SecretVersion empty, an empty alias is not defaulted to "latest":
+// NewSecretWithConfig rejects it with ErrInvalidVersion.
+func (c Config) WithVersionAlias(alias string) Config {
+	c.SecretVersion = alias
+	c.versionRequired = true
+	return c
+}
+
+// validateVersion checks that version can be used as the last segment of a
+// secret version resource path.
+//
+// Parameters:
+// - version: The secret version, alias or "latest".
+//
+// Returns:
+// - An error wrapping ErrInvalidVersion if the version is empty or contains
+// characters that are not allowed.
+func validateVersion(version string) error {
+	if version == "" {
+		return fmt.Errorf("%w: version must not be empty", ErrInvalidVersion)
+	}
+	if !versionAliasPattern.MatchString(version) {
+		return fmt.Errorf("%w: %q may only contain letters, digits, '-' and '_'", ErrInvalidVersion, version)
+	}
+	return nil
+}
+
+// VersionState is the lifecycle state of a secret version.
+type VersionState string
+
+const (
+	// VersionStateEnabled means the version can be accessed.
+	VersionStateEnabled VersionState = "ENABLED"
+	// VersionStateDisabled means the version cannot be accessed but can be re-enabled.
+	VersionStateDisabled VersionState = "DISABLED"
+	// VersionStateDestroyed means the version data was permanently removed.
+	VersionStateDestroyed VersionState = "DESTROYED"
+)
+
+// VersionInfo describes a resolved secret version.
+type VersionInfo struct {
+	// Name is the full resource name of the version, with "latest" or an
+	// alias resolved to the concrete version number
+	Name string
+	// Version is the concrete version number, the last segment of Name
+	Version string
+	// State is the current state of the version
+	State VersionState
+	// CreateTime is the time the version was created
+	CreateTime time.Time
+}
+
+// GetSecretVersionInfo retrieves the metadata of the configured secret
+// version without accessing its payload. It can be used to detect, for
+// example, that "latest" points to a disabled version before reading it.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+//
+// Returns:
+// - A VersionInfo describing the resolved version.
+// - An error if the metadata retrieval fails.
+func (c *Client) GetSecretVersionInfo(ctx context.Context) (*VersionInfo, error) {
+	// Create the request to get the secret version metadata
+	req := &secretmanagerpb.GetSecretVersionRequest{
+		Name: c.config.versionPath(c.config.SecretName, c.config.SecretVersion),
+	}
+
+	var result *secretmanagerpb.SecretVersion
+	err := withRetry(ctx, c.config.Retry, func() error {
+		// Add a timeout to the context to limit the duration of each API call
+		callCtx, cancel := c.config.callContext(ctx)
+		defer cancel()
+
+		var err error
+		result, err = c.client.GetSecretVersion(callCtx, req)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret version: %w", classifyError(err))
+	}
+
+	return newVersionInfo(result), nil
+}
+
+// newVersionInfo converts the API representation of a version into a VersionInfo.
+func newVersionInfo(v *secretmanagerpb.SecretVersion) *VersionInfo {
+	info := &VersionInfo{
+		Name:  v.GetName(),
+		State: VersionState(v.GetState().String()),
+	}
+	if i := strings.LastIndex(info.Name, "/"); i >= 0 {
+		info.Version = info.Name[i+1:]
+	}
+	if v.GetCreateTime() != nil {
+		info.CreateTime = v.GetCreateTime().AsTime()
+	}
+	return info
+}
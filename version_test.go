@@ -0,0 +1,166 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// nameRecorder records the resource name of every accessed secret version.
+type nameRecorder struct {
+	mockSecretManagerClient
+	names []string
+}
+
+func (m *nameRecorder) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	m.names = append(m.names, req.Name)
+	return m.mockSecretManagerClient.AccessSecretVersion(ctx, req, opts...)
+}
+
+func TestKindOfVersion(t *testing.T) {
+	assert.Equal(t, VersionLatest, KindOfVersion(""))
+	assert.Equal(t, VersionLatest, KindOfVersion("latest"))
+	assert.Equal(t, VersionNumber, KindOfVersion("7"))
+	assert.Equal(t, VersionAlias, KindOfVersion("prod"))
+	assert.Equal(t, VersionAlias, KindOfVersion("v2-prod"))
+}
+
+func TestWithVersionAlias(t *testing.T) {
+	originDefaultClientFactory := defaultClientFactory
+	defer func() {
+		defaultClientFactory = originDefaultClientFactory
+	}()
+
+	recorder := &nameRecorder{mockSecretManagerClient: mockSecretManagerClient{isSuccess: true}}
+	defaultClientFactory = func(ctx context.Context, opts ...option.ClientOption) (SecretManagerClient, error) {
+		return recorder, nil
+	}
+
+	ctx := context.Background()
+	base := Config{ProjectID: "test-id", SecretName: "test-name"}
+
+	testCases := []struct {
+		name         string
+		config       Config
+		expectedPath string
+		expectedErr  error
+	}{
+		{
+			name:         "alias flows into the resource path",
+			config:       base.WithVersionAlias("prod"),
+			expectedPath: "projects/test-id/secrets/test-name/versions/prod",
+		},
+		{
+			name:        "empty alias is rejected",
+			config:      base.WithVersionAlias(""),
+			expectedErr: ErrInvalidVersion,
+		},
+		{
+			name:        "alias with slash is rejected",
+			config:      base.WithVersionAlias("prod/1"),
+			expectedErr: ErrInvalidVersion,
+		},
+		{
+			name:         "empty version defaults to latest",
+			config:       base,
+			expectedPath: "projects/test-id/secrets/test-name/versions/latest",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder.names = nil
+
+			client, err := NewSecret(ctx, tc.config)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			_, err = client.GetSecret(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tc.expectedPath}, recorder.names)
+			assert.False(t, strings.Contains(tc.expectedPath, "//"))
+		})
+	}
+}
+
+func TestGetSecretVersionInfo(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		mock         *mockSecretManagerClient
+		expectedInfo *VersionInfo
+		expectedErr  error
+		expectedIs   error
+	}{
+		{
+			name: "success with disabled version",
+			mock: &mockSecretManagerClient{
+				version: &secretmanagerpb.SecretVersion{
+					Name:       "projects/test-id/secrets/test-name/versions/7",
+					State:      secretmanagerpb.SecretVersion_DISABLED,
+					CreateTime: timestamppb.New(created),
+				},
+			},
+			expectedInfo: &VersionInfo{
+				Name:       "projects/test-id/secrets/test-name/versions/7",
+				Version:    "7",
+				State:      VersionStateDisabled,
+				CreateTime: created,
+			},
+		},
+		{
+			name: "success resolves latest",
+			mock: &mockSecretManagerClient{isSuccess: true},
+			expectedInfo: &VersionInfo{
+				Name:    "projects/test-id/secrets/test-name/versions/1",
+				Version: "1",
+				State:   VersionStateEnabled,
+			},
+		},
+		{
+			name:        "fail to get secret version",
+			mock:        &mockSecretManagerClient{},
+			expectedErr: fmt.Errorf("failed to get secret version"),
+		},
+		{
+			name:        "fail with missing secret",
+			mock:        &mockSecretManagerClient{errs: []error{status.Error(codes.NotFound, "secret not found")}},
+			expectedErr: fmt.Errorf("failed to get secret version"),
+			expectedIs:  ErrSecretNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{
+				client: tc.mock,
+				config: &Config{ProjectID: "test-id", SecretName: "test-name", SecretVersion: "latest"},
+			}
+
+			info, err := c.GetSecretVersionInfo(context.Background())
+			if tc.expectedErr != nil {
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+				if tc.expectedIs != nil {
+					assert.ErrorIs(t, err, tc.expectedIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedInfo, info)
+		})
+	}
+}
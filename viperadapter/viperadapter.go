@@ -0,0 +1,59 @@
+// Package viperadapter feeds secrets from Google Cloud Secret Manager into
+// spf13/viper. It relies on the MergeConfigMap method only, so neither this
+// package nor the main package depends on viper.
+package viperadapter
+
+import (
+	"context"
+
+	secretmgr "github.com/TTEC-Engage-Digital/GCPSecretManager"
+)
+
+// ConfigMerger is implemented by *viper.Viper.
+type ConfigMerger interface {
+	MergeConfigMap(cfg map[string]interface{}) error
+}
+
+// ConfigMap retrieves the secret and returns its key-value pairs typed for
+// viper.MergeConfigMap. Like GetSecretAsMap, it leaves the process
+// environment untouched.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - c: The client used to access the secret.
+//
+// Returns:
+// - A map containing the parsed key-value pairs, also returned alongside the
+// error when the client is configured with ContinueOnError.
+// - An error if the secret retrieval or parsing fails.
+func ConfigMap(ctx context.Context, c *secretmgr.Client) (map[string]interface{}, error) {
+	values, err := c.GetSecretAsMap(ctx)
+	if values == nil {
+		return nil, err
+	}
+
+	cfg := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		cfg[key] = value
+	}
+	return cfg, err
+}
+
+// Merge retrieves the secret and merges its key-value pairs into v, for
+// example a *viper.Viper, on top of the configuration it already holds.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - c: The client used to access the secret.
+// - v: The configuration to merge the secret into.
+//
+// Returns:
+// - An error if the secret retrieval, parsing or merge fails. Nothing is
+// merged when the secret cannot be retrieved or parsed.
+func Merge(ctx context.Context, c *secretmgr.Client, v ConfigMerger) error {
+	cfg, err := ConfigMap(ctx, c)
+	if err != nil {
+		return err
+	}
+	return v.MergeConfigMap(cfg)
+}
@@ -0,0 +1,88 @@
+package viperadapter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	secretmgr "github.com/TTEC-Engage-Digital/GCPSecretManager"
+	"github.com/stretchr/testify/assert"
+)
+
+// mapMerger records merged configurations like viper.MergeConfigMap.
+type mapMerger struct {
+	cfg map[string]interface{}
+	err error
+}
+
+func (m *mapMerger) MergeConfigMap(cfg map[string]interface{}) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.cfg == nil {
+		m.cfg = make(map[string]interface{})
+	}
+	for key, value := range cfg {
+		m.cfg[key] = value
+	}
+	return nil
+}
+
+func newLocalClient(t *testing.T, payload string) *secretmgr.Client {
+	path := filepath.Join(t.TempDir(), ".env")
+	assert.NoError(t, os.WriteFile(path, []byte(payload), 0o600))
+
+	client, err := secretmgr.NewSecret(context.Background(), secretmgr.Config{LocalFile: path})
+	assert.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestMerge(t *testing.T) {
+	testCases := []struct {
+		name        string
+		payload     string
+		merger      *mapMerger
+		expected    map[string]interface{}
+		expectedErr string
+	}{
+		{
+			name:     "merge over existing configuration",
+			payload:  "DB_HOST=db\nDB_PORT=5432\n",
+			merger:   &mapMerger{cfg: map[string]interface{}{"DB_HOST": "localhost", "LOG_LEVEL": "info"}},
+			expected: map[string]interface{}{"DB_HOST": "db", "DB_PORT": "5432", "LOG_LEVEL": "info"},
+		},
+		{
+			name:        "parse error",
+			payload:     "broken\n",
+			merger:      &mapMerger{},
+			expectedErr: "failed to parse secret",
+		},
+		{
+			name:        "merge error",
+			payload:     "DB_HOST=db\n",
+			merger:      &mapMerger{err: fmt.Errorf("merge error")},
+			expectedErr: "merge error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Merge(context.Background(), newLocalClient(t, tc.payload), tc.merger)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, tc.merger.cfg)
+		})
+	}
+}
+
+func TestConfigMap(t *testing.T) {
+	cfg, err := ConfigMap(context.Background(), newLocalClient(t, "A=1\n# comment\nB=two\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"A": "1", "B": "two"}, cfg)
+}
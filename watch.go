@@ -0,0 +1,83 @@
+package GCPSecretManager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+)
+
+// WatchSecret polls the latest version of the configured secret every
+// interval and calls onChange with the new value whenever the payload
+// changes. The value fetched when the watch starts is not reported. Payloads
+// are compared by their CRC32C checksum when Secret Manager provides one.
+//
+// Errors while polling are logged and the watch keeps going, so a transient
+// outage does not stop the reload of rotated secrets.
+//
+// Parameters:
+// - ctx: The context controlling the watch; cancelling it stops the watch.
+// - interval: The delay between two polls, which must be positive.
+// - onChange: The callback invoked with each new secret value.
+//
+// Returns:
+// - nil once ctx is cancelled.
+// - An error if interval is not positive or the initial fetch fails.
+func (c *Client) WatchSecret(ctx context.Context, interval time.Duration, onChange func(newValue string)) error {
+	if interval <= 0 {
+		return fmt.Errorf("watch interval must be positive, got %s", interval)
+	}
+
+	// Fetch the initial value without reporting it
+	last, err := c.accessSecretVersion(ctx, c.config.SecretName, LatestVersion)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve secret: %w", err)
+	}
+
+	c.pollSecret(ctx, interval, last, onChange, nil)
+	return nil
+}
+
+// pollSecret polls the latest version of the configured secret every interval
+// until ctx is cancelled, calling onChange whenever the payload differs from
+// last. Polling errors are logged and passed to onError when it is not nil.
+func (c *Client) pollSecret(ctx context.Context, interval time.Duration, last *secretmanagerpb.AccessSecretVersionResponse, onChange func(newValue string), onError func(err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := c.accessSecretVersion(ctx, c.config.SecretName, LatestVersion)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			c.config.logger().Warn().Err(err).Str("secret", c.config.SecretName).Msg("Failed to poll secret")
+			if onError != nil {
+				onError(err)
+			}
+			continue
+		}
+
+		if payloadChanged(last.Payload, current.Payload) {
+			last = current
+			onChange(trimBOM(string(current.Payload.Data)))
+		}
+	}
+}
+
+// payloadChanged reports whether two payloads differ, comparing their CRC32C
+// checksums when both carry one and their data otherwise.
+func payloadChanged(previous, current *secretmanagerpb.SecretPayload) bool {
+	if previous.DataCrc32C != nil && current.DataCrc32C != nil {
+		return previous.GetDataCrc32C() != current.GetDataCrc32C()
+	}
+	return !bytes.Equal(previous.Data, current.Data)
+}
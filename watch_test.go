@@ -0,0 +1,107 @@
+package GCPSecretManager
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"sync"
+	"testing"
+	"time"
+
+	secretmanagerpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// sequenceMockClient returns the next payload of a sequence on every call and
+// keeps returning the last one once the sequence is exhausted. An empty
+// payload simulates an access error.
+type sequenceMockClient struct {
+	mockSecretManagerClient
+	mu       sync.Mutex
+	payloads []string
+	names    []string
+}
+
+func (m *sequenceMockClient) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.names = append(m.names, req.Name)
+	payload := m.payloads[0]
+	if len(m.payloads) > 1 {
+		m.payloads = m.payloads[1:]
+	}
+	if payload == "" {
+		return nil, fmt.Errorf("access error")
+	}
+
+	sum := int64(crc32.Checksum([]byte(payload), crc32.MakeTable(crc32.Castagnoli)))
+	return &secretmanagerpb.AccessSecretVersionResponse{
+		Payload: &secretmanagerpb.SecretPayload{
+			Data:       []byte(payload),
+			DataCrc32C: &sum,
+		},
+	}, nil
+}
+
+func TestWatchSecret(t *testing.T) {
+	mock := &sequenceMockClient{payloads: []string{"v1", "v1", "", "v2", "v2", "v3"}}
+	c := &Client{client: mock, config: &Config{ProjectID: "p", SecretName: "s", SecretVersion: "3"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var changes []string
+	err := c.WatchSecret(ctx, time.Millisecond, func(newValue string) {
+		changes = append(changes, newValue)
+		if newValue == "v3" {
+			cancel()
+		}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v2", "v3"}, changes)
+	assert.Equal(t, "projects/p/secrets/s/versions/latest", mock.names[0])
+}
+
+func TestWatchSecretStripsBOM(t *testing.T) {
+	mock := &sequenceMockClient{payloads: []string{"\ufeffv1", "\ufeffv2"}}
+	c := &Client{client: mock, config: &Config{ProjectID: "p", SecretName: "s"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var changes []string
+	err := c.WatchSecret(ctx, time.Millisecond, func(newValue string) {
+		changes = append(changes, newValue)
+		cancel()
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v2"}, changes)
+}
+
+func TestWatchSecretErrors(t *testing.T) {
+	c := &Client{client: &sequenceMockClient{payloads: []string{""}}, config: &Config{}}
+
+	err := c.WatchSecret(context.Background(), time.Millisecond, func(string) {})
+	assert.ErrorContains(t, err, "failed to retrieve secret")
+
+	err = c.WatchSecret(context.Background(), 0, func(string) {})
+	assert.ErrorContains(t, err, "watch interval must be positive")
+}
+
+func TestPayloadChanged(t *testing.T) {
+	one, two := int64(1), int64(2)
+	assert.False(t, payloadChanged(
+		&secretmanagerpb.SecretPayload{Data: []byte("a"), DataCrc32C: &one},
+		&secretmanagerpb.SecretPayload{Data: []byte("b"), DataCrc32C: &one},
+	))
+	assert.True(t, payloadChanged(
+		&secretmanagerpb.SecretPayload{Data: []byte("a"), DataCrc32C: &one},
+		&secretmanagerpb.SecretPayload{Data: []byte("a"), DataCrc32C: &two},
+	))
+	assert.True(t, payloadChanged(
+		&secretmanagerpb.SecretPayload{Data: []byte("a")},
+		&secretmanagerpb.SecretPayload{Data: []byte("b")},
+	))
+}
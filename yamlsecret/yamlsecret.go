@@ -0,0 +1,92 @@
+// Package yamlsecret loads YAML secrets from Google Cloud Secret Manager into
+// environment variables. It is kept apart from the main package so that only
+// callers that need YAML depend on a YAML parser.
+package yamlsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	secretmgr "github.com/TTEC-Engage-Digital/GCPSecretManager"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidYAML is returned when a secret payload is not a YAML mapping.
+var ErrInvalidYAML = errors.New("secret is not a valid YAML mapping")
+
+// LoadYAMLSecretToEnv retrieves the secret from Secret Manager, decodes it as a
+// YAML mapping and sets each key as an environment variable. Nested mappings
+// are flattened by joining the keys with Config.JSONSeparator, exactly like
+// Client.LoadJSONSecretToEnv does, and sequences are JSON-encoded.
+//
+// Parameters:
+// - ctx: The context for the request, used for cancellation and timeouts.
+// - c: The client used to access the secret.
+//
+// Returns:
+// - An error wrapping ErrInvalidYAML if the payload is not a YAML mapping.
+// - An error if the secret retrieval or environment variable setting fails.
+func LoadYAMLSecretToEnv(ctx context.Context, c *secretmgr.Client) error {
+	return c.LoadDecodedSecretToEnv(ctx, decodeYAML)
+}
+
+// decodeYAML decodes data as a YAML mapping. Sequences, scalars and empty
+// documents are rejected.
+func decodeYAML(data []byte) (map[string]any, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidYAML, err)
+	}
+	if len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: top-level value must be a mapping", ErrInvalidYAML)
+	}
+
+	var decoded map[string]any
+	if err := node.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidYAML, err)
+	}
+
+	normalized, err := normalize(decoded)
+	if err != nil {
+		return nil, err
+	}
+	return normalized.(map[string]any), nil
+}
+
+// normalize converts the mappings produced by the YAML decoder into
+// map[string]any so that they are flattened like decoded JSON objects.
+func normalize(v any) (any, error) {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			n, err := normalize(item)
+			if err != nil {
+				return nil, err
+			}
+			val[k] = n
+		}
+		return val, nil
+	case map[any]any:
+		m := make(map[string]any, len(val))
+		for k, item := range val {
+			n, err := normalize(item)
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(k)] = n
+		}
+		return m, nil
+	case []any:
+		for i, item := range val {
+			n, err := normalize(item)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = n
+		}
+		return val, nil
+	default:
+		return val, nil
+	}
+}
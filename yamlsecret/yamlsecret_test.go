@@ -0,0 +1,76 @@
+package yamlsecret
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	secretmgr "github.com/TTEC-Engage-Digital/GCPSecretManager"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadYAMLSecretToEnv(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name      string
+		payload   string
+		separator string
+		expected  map[string]string
+		wantErr   error
+	}{
+		{
+			name:    "flat mapping",
+			payload: "YAML_NAME: app\nYAML_PORT: 8080\nYAML_DEBUG: true\nYAML_EMPTY: null\n",
+			expected: map[string]string{
+				"YAML_NAME":  "app",
+				"YAML_PORT":  "8080",
+				"YAML_DEBUG": "true",
+				"YAML_EMPTY": "",
+			},
+		},
+		{
+			name:    "nested mapping and sequence",
+			payload: "YAML_DB:\n  HOST: localhost\n  PORTS: [1, 2]\n",
+			expected: map[string]string{
+				"YAML_DB_HOST":  "localhost",
+				"YAML_DB_PORTS": "[1,2]",
+			},
+		},
+		{
+			name:      "custom separator",
+			payload:   "YAML_DB:\n  HOST: localhost\n",
+			separator: "__",
+			expected:  map[string]string{"YAML_DB__HOST": "localhost"},
+		},
+		{name: "sequence payload", payload: "- a\n- b\n", wantErr: ErrInvalidYAML},
+		{name: "scalar payload", payload: "just text\n", wantErr: ErrInvalidYAML},
+		{name: "empty payload", payload: "", wantErr: ErrInvalidYAML},
+		{name: "malformed payload", payload: "a: [b\n", wantErr: ErrInvalidYAML},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "secret.yaml")
+			assert.NoError(t, os.WriteFile(path, []byte(tc.payload), 0o600))
+			for key := range tc.expected {
+				t.Setenv(key, "")
+			}
+
+			client, err := secretmgr.NewSecret(ctx, secretmgr.Config{LocalFile: path, JSONSeparator: tc.separator})
+			assert.NoError(t, err)
+			defer client.Close()
+
+			err = LoadYAMLSecretToEnv(ctx, client)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			for key, value := range tc.expected {
+				assert.Equal(t, value, os.Getenv(key))
+			}
+		})
+	}
+}